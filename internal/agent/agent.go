@@ -19,6 +19,69 @@ type Config struct {
 
 	// FilePatterns define additional files to include in analysis
 	FilePatterns []string `yaml:"file_patterns"`
+
+	// Frontmatter maps a scope type (subagent, skill, command) to the schema
+	// its markdown frontmatter must follow
+	Frontmatter map[string]FrontmatterSchema `yaml:"frontmatter"`
+
+	// Models are the accepted model aliases (full model IDs are accepted by prefix)
+	Models ModelNames `yaml:"models"`
+
+	// Imports configure how the agent follows file imports
+	Imports Imports `yaml:"imports"`
+
+	// TokenBudgets maps a scope type (main, subagent, command, skill,
+	// output-style) to the number of tokens its files should stay within
+	TokenBudgets map[string]int `yaml:"token_budgets"`
+}
+
+// TokenBudget returns the token budget for a scope type, or 0 if it has none
+func (c *Config) TokenBudget(scopeType string) int {
+	return c.TokenBudgets[scopeType]
+}
+
+// DefaultMaxImportDepth is the import depth used when the config sets none
+const DefaultMaxImportDepth = 5
+
+// Imports describe the agent's import behaviour
+type Imports struct {
+	// MaxDepth is the number of import hops the agent follows from an
+	// entrypoint; imports beyond it are silently dropped
+	MaxDepth int `yaml:"max_depth"`
+}
+
+// MaxImportDepth returns the configured import depth, or the default
+func (c *Config) MaxImportDepth() int {
+	if c.Imports.MaxDepth > 0 {
+		return c.Imports.MaxDepth
+	}
+	return DefaultMaxImportDepth
+}
+
+// FrontmatterSchema describes the frontmatter fields allowed for a file type
+type FrontmatterSchema struct {
+	// Required fields must be present and non-empty
+	Required []string `yaml:"required"`
+
+	// Fields maps each allowed field to its type: string, bool, list
+	// (a YAML list or comma-separated string), model, color or any
+	Fields map[string]string `yaml:"fields"`
+
+	// NameMatchesFile requires the name field to match the file (or skill
+	// directory) name
+	NameMatchesFile bool `yaml:"name_matches_file"`
+
+	// Colors are the accepted values for color fields
+	Colors []string `yaml:"colors"`
+}
+
+// ModelNames lists accepted model values
+type ModelNames struct {
+	// Aliases are short model names (e.g., "sonnet", "inherit")
+	Aliases []string `yaml:"aliases"`
+
+	// Prefixes are accepted prefixes for full model IDs (e.g., "claude-")
+	Prefixes []string `yaml:"prefixes"`
 }
 
 // ReferencePattern defines a pattern for extracting references from text
@@ -29,6 +92,16 @@ type ReferencePattern struct {
 	// Type categorizes the reference (file, url, tool, etc.)
 	Type string `yaml:"type"`
 
+	// SkipCodeBlocks ignores matches inside fenced or indented code blocks
+	SkipCodeBlocks bool `yaml:"skip_code_blocks"`
+
+	// SkipCodeSpans ignores matches inside inline code spans
+	SkipCodeSpans bool `yaml:"skip_code_spans"`
+
+	// Import marks matches as imports the agent loads into context. Imports
+	// resolve relative to the importing file rather than the project root.
+	Import bool `yaml:"import"`
+
 	// compiled is the compiled regex
 	compiled *regexp.Regexp
 }
@@ -59,6 +132,10 @@ type Markers struct {
 	// LowPriority keywords indicate suggestions
 	LowPriority []string `yaml:"low_priority"`
 
+	// Prohibitive keywords negate the instruction that follows them
+	// (e.g., "NEVER force push" forbids rather than requests a force push)
+	Prohibitive []string `yaml:"prohibitive"`
+
 	// Sections define expected section headers
 	Sections []string `yaml:"sections"`
 }
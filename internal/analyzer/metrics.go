@@ -12,6 +12,7 @@ type Metrics struct {
 	TotalBytes       int
 	ReferencesByType map[string]int
 	FilesByType      map[string]int
+	TokensByCategory map[string]int
 	MaxDepth         int
 	UnresolvedRefs   int
 }
@@ -21,6 +22,7 @@ func ComputeMetrics(tree *Tree) *Metrics {
 	m := &Metrics{
 		ReferencesByType: make(map[string]int),
 		FilesByType:      make(map[string]int),
+		TokensByCategory: make(map[string]int),
 	}
 
 	for _, node := range tree.Nodes {
@@ -31,18 +33,18 @@ func ComputeMetrics(tree *Tree) *Metrics {
 		m.TotalFiles++
 		m.TotalBytes += len(node.Content)
 
-		// Estimate tokens (rough: ~4 chars per token)
-		m.EstimatedTokens += len(node.Content) / 4
+		m.EstimatedTokens += EstimateTokens(node.Content)
 
 		// Track max depth
 		if node.Depth > m.MaxDepth {
 			m.MaxDepth = node.Depth
 		}
 
-		// Count file types
+		// Count file types and tokens per category
 		if node.Parsed != nil {
 			fileType := fileTypeToString(node.Parsed.FileType)
 			m.FilesByType[fileType]++
+			m.TokensByCategory[node.Parsed.Category.String()] += EstimateTokens(node.Content)
 		}
 
 		// Count references
@@ -59,6 +61,11 @@ func ComputeMetrics(tree *Tree) *Metrics {
 	return m
 }
 
+// EstimateTokens roughly estimates the tokens in content (~4 chars per token)
+func EstimateTokens(content []byte) int {
+	return len(content) / 4
+}
+
 // fileTypeToString converts a parser.FileType to string
 func fileTypeToString(ft parser.FileType) string {
 	switch ft {
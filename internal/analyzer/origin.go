@@ -0,0 +1,226 @@
+package analyzer
+
+import (
+	"net/url"
+	"regexp"
+	"strings"
+
+	"github.com/pthm/cclint/internal/agent"
+	"github.com/pthm/cclint/internal/parser"
+)
+
+// RefOrigin describes where in a file a reference was found
+type RefOrigin int
+
+const (
+	// RefOriginProse is ordinary text (and any text in non-markdown files)
+	RefOriginProse RefOrigin = iota
+	// RefOriginCode is inside a fenced or indented code block
+	RefOriginCode
+	// RefOriginInlineCode is inside an inline code span
+	RefOriginInlineCode
+	// RefOriginLink is the destination of a markdown link
+	RefOriginLink
+	// RefOriginFrontmatter is inside YAML frontmatter
+	RefOriginFrontmatter
+)
+
+func (o RefOrigin) String() string {
+	switch o {
+	case RefOriginProse:
+		return "prose"
+	case RefOriginCode:
+		return "code"
+	case RefOriginInlineCode:
+		return "inline-code"
+	case RefOriginLink:
+		return "link"
+	case RefOriginFrontmatter:
+		return "frontmatter"
+	default:
+		return "unknown"
+	}
+}
+
+// originMap classifies positions in a file by markdown structure
+type originMap struct {
+	lines       []string
+	frontmatter int          // Last frontmatter line (0 if none)
+	codeLines   map[int]bool // 1-based lines inside code blocks, including fences
+	links       map[int][][2]int
+	spans       map[int][][2]int
+}
+
+// newOriginMap builds an origin map for a parsed file. Files that are not
+// markdown classify everything as prose.
+func newOriginMap(lines []string, parsed *parser.ParsedFile) *originMap {
+	m := &originMap{
+		lines:     lines,
+		codeLines: make(map[int]bool),
+		links:     make(map[int][][2]int),
+		spans:     make(map[int][][2]int),
+	}
+	if parsed == nil || parsed.FileType != parser.FileTypeMarkdown {
+		return m
+	}
+
+	if fm := parser.ParseFrontmatterBlock(parsed.Content); fm != nil {
+		m.frontmatter = fm.EndLine
+	}
+
+	for _, block := range parsed.Blocks {
+		if block.Kind != parser.BlockCode {
+			continue
+		}
+		for line := block.StartLine; line <= block.EndLine; line++ {
+			m.codeLines[line] = true
+		}
+		// Fence lines belong to the block too
+		for _, line := range []int{block.StartLine - 1, block.EndLine + 1} {
+			if line >= 1 && line <= len(lines) && isFence(lines[line-1]) {
+				m.codeLines[line] = true
+			}
+		}
+	}
+
+	for _, link := range parsed.Links {
+		if link.Line < 1 || link.Line > len(lines) || link.Column < 1 {
+			continue
+		}
+		line := lines[link.Line-1]
+		marker := "](" + link.Destination
+		if link.AutoLink {
+			marker = "<" + link.Destination
+		}
+		from := link.Column - 1
+		if from > len(line) {
+			continue
+		}
+		if idx := strings.Index(line[from:], marker); idx >= 0 {
+			start := from + idx + len(marker) - len(link.Destination)
+			m.links[link.Line] = append(m.links[link.Line], [2]int{start, start + len(link.Destination)})
+		}
+	}
+
+	return m
+}
+
+// origin classifies the byte range [start, end) of a 1-based line
+func (m *originMap) origin(line, start, end int) RefOrigin {
+	if line <= m.frontmatter {
+		return RefOriginFrontmatter
+	}
+	if m.codeLines[line] {
+		return RefOriginCode
+	}
+	for _, r := range m.links[line] {
+		if start >= r[0] && end <= r[1] {
+			return RefOriginLink
+		}
+	}
+	spans, ok := m.spans[line]
+	if !ok && line <= len(m.lines) {
+		spans = codeSpans(m.lines[line-1])
+		m.spans[line] = spans
+	}
+	for _, r := range spans {
+		if start >= r[0] && end <= r[1] {
+			return RefOriginInlineCode
+		}
+	}
+	return RefOriginProse
+}
+
+// codeSpans returns the byte ranges of inline code spans in a line,
+// including their backticks
+func codeSpans(line string) [][2]int {
+	var spans [][2]int
+	for i := 0; i < len(line); {
+		if line[i] != '`' {
+			i++
+			continue
+		}
+		run := backtickRun(line, i)
+		closing := -1
+		for j := i + run; j < len(line); {
+			if line[j] != '`' {
+				j++
+				continue
+			}
+			n := backtickRun(line, j)
+			if n == run {
+				closing = j
+				break
+			}
+			j += n
+		}
+		if closing < 0 {
+			i += run
+			continue
+		}
+		spans = append(spans, [2]int{i, closing + run})
+		i = closing + run
+	}
+	return spans
+}
+
+// backtickRun returns the number of consecutive backticks at line[i:]
+func backtickRun(line string, i int) int {
+	n := 0
+	for i+n < len(line) && line[i+n] == '`' {
+		n++
+	}
+	return n
+}
+
+// isFence reports whether a line is a code fence
+func isFence(line string) bool {
+	trimmed := strings.TrimSpace(line)
+	return strings.HasPrefix(trimmed, "```") || strings.HasPrefix(trimmed, "~~~")
+}
+
+// urlSchemePattern matches destinations with a URL scheme (https:, mailto:, ...)
+var urlSchemePattern = regexp.MustCompile(`^[a-zA-Z][a-zA-Z0-9+.-]*:`)
+
+// linkReferences returns link references for relative markdown links and
+// images, including same-file #anchors. External links are left to the URL
+// reference pattern.
+func linkReferences(lines []string, path string, parsed *parser.ParsedFile, agentConfig *agent.Config) []Reference {
+	if parsed == nil || parsed.FileType != parser.FileTypeMarkdown {
+		return nil
+	}
+
+	var refs []Reference
+	for _, link := range parsed.Links {
+		if link.AutoLink || urlSchemePattern.MatchString(link.Destination) || strings.HasPrefix(link.Destination, "//") {
+			continue
+		}
+		target, fragment, _ := strings.Cut(link.Destination, "#")
+		target, _, _ = strings.Cut(target, "?")
+		if unescaped, err := url.PathUnescape(target); err == nil {
+			target = unescaped
+		}
+		if unescaped, err := url.PathUnescape(fragment); err == nil {
+			fragment = unescaped
+		}
+		if target == "" && fragment == "" {
+			continue
+		}
+
+		refs = append(refs, Reference{
+			Type:     RefTypeLink,
+			Value:    target,
+			Fragment: fragment,
+			Image:    link.Image,
+			Source: Location{
+				File:   path,
+				Line:   link.Line,
+				Column: link.Column,
+			},
+			Priority: calculatePriority(lines, link.Line-1, agentConfig),
+			Context:  getContext(lines, link.Line-1, 2),
+			Origin:   RefOriginLink,
+		})
+	}
+	return refs
+}
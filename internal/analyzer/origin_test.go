@@ -0,0 +1,154 @@
+package analyzer
+
+import (
+	"path/filepath"
+	"testing"
+
+	"github.com/pthm/cclint/internal/agent"
+)
+
+func TestExtractReferencesOrigins(t *testing.T) {
+	tmpDir := t.TempDir()
+	writeFiles(t, tmpDir, map[string]string{
+		"CLAUDE.md": "---\n" +
+			"description: see `docs/meta.md`\n" +
+			"---\n" +
+			"Read @docs/guide.md first.\n" +
+			"Do not import `@docs/span.md` here.\n" +
+			"See [the guide](https://example.com/guide) and [setup](docs/setup.md).\n" +
+			"\n" +
+			"```bash\n" +
+			"cat @example/fake.ts\n" +
+			"```\n" +
+			"\n" +
+			"```json\n" +
+			"\"mcpServers\": {\"github\"}\n" +
+			"```\n",
+		"docs/guide.md": "# Guide",
+	})
+
+	agentConfig, err := agent.Load("claude-code")
+	if err != nil {
+		t.Fatalf("Failed to load agent config: %v", err)
+	}
+
+	tree, err := BuildTree(tmpDir, agentConfig)
+	if err != nil {
+		t.Fatalf("BuildTree() error: %v", err)
+	}
+
+	node := tree.Nodes[filepath.Join(tmpDir, "CLAUDE.md")]
+	if node == nil {
+		t.Fatal("CLAUDE.md not in tree")
+	}
+
+	origins := make(map[string]RefOrigin)
+	for _, ref := range node.References {
+		origins[ref.Value] = ref.Origin
+	}
+
+	want := map[string]RefOrigin{
+		"docs/meta.md":  RefOriginFrontmatter,
+		"docs/guide.md": RefOriginProse,
+		"docs/setup.md": RefOriginLink,
+		"github":        RefOriginCode,
+	}
+	for value, origin := range want {
+		got, ok := origins[value]
+		if !ok {
+			t.Errorf("Reference %q not extracted (got %v)", value, origins)
+			continue
+		}
+		if got != origin {
+			t.Errorf("Reference %q origin = %s, want %s", value, got, origin)
+		}
+	}
+
+	// Column and EndColumn bound the reference value on its line
+	for _, ref := range node.References {
+		if ref.Value == "docs/guide.md" && (ref.Source.Line != 4 || ref.Source.Column != 7 || ref.EndColumn != 20) {
+			t.Errorf("docs/guide.md at %d:%d-%d, want 4:7-20", ref.Source.Line, ref.Source.Column, ref.EndColumn)
+		}
+	}
+
+	// Imports are not evaluated inside code blocks or code spans, and
+	// patterns without a capture group such as URLs produce no references
+	for _, skipped := range []string{"example/fake.ts", "docs/span.md", "https://example.com/guide"} {
+		if _, ok := origins[skipped]; ok {
+			t.Errorf("Reference %q should not be extracted", skipped)
+		}
+	}
+}
+
+func TestCodeSpans(t *testing.T) {
+	tests := []struct {
+		line string
+		want [][2]int
+	}{
+		{"no code", nil},
+		{"use `a` and `b`", [][2]int{{4, 7}, {12, 15}}},
+		{"``a ` b`` end", [][2]int{{0, 9}}},
+		{"unclosed `tick", nil},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.line, func(t *testing.T) {
+			got := codeSpans(tt.line)
+			if len(got) != len(tt.want) {
+				t.Fatalf("codeSpans(%q) = %v, want %v", tt.line, got, tt.want)
+			}
+			for i := range got {
+				if got[i] != tt.want[i] {
+					t.Errorf("codeSpans(%q) = %v, want %v", tt.line, got, tt.want)
+				}
+			}
+		})
+	}
+}
+
+func TestLinkReferencesNotFollowed(t *testing.T) {
+	tmpDir := t.TempDir()
+	writeFiles(t, tmpDir, map[string]string{
+		"CLAUDE.md":     "![arch](docs/arch.png)\n\nSee [the guide](docs/guide.md#setup).\n",
+		"docs/arch.png": "\x89PNG\r\n\x1a\n\x00\x00\x00\rIHDR",
+		"docs/guide.md": "# Guide\n\n## Setup\n",
+	})
+
+	agentConfig, err := agent.Load("claude-code")
+	if err != nil {
+		t.Fatalf("Failed to load agent config: %v", err)
+	}
+
+	tree, err := BuildTree(tmpDir, agentConfig)
+	if err != nil {
+		t.Fatalf("BuildTree() error: %v", err)
+	}
+
+	// Links are checked, not loaded: neither target joins the tree
+	for _, name := range []string{"docs/arch.png", "docs/guide.md"} {
+		if _, ok := tree.Nodes[filepath.Join(tmpDir, name)]; ok {
+			t.Errorf("Linked %s should not be loaded into the tree", name)
+		}
+	}
+
+	node := tree.Nodes[filepath.Join(tmpDir, "CLAUDE.md")]
+	if node == nil {
+		t.Fatal("CLAUDE.md not in tree")
+	}
+	if len(node.Children) != 0 {
+		t.Errorf("CLAUDE.md has %d children, want 0", len(node.Children))
+	}
+
+	links := make(map[string]Reference)
+	for _, ref := range node.References {
+		if ref.Type == RefTypeLink {
+			links[ref.Value] = ref
+		}
+	}
+	if image, ok := links["docs/arch.png"]; !ok || !image.Image || !image.Resolved {
+		t.Errorf("Image link = %+v, want a resolved image", image)
+	}
+	if guide, ok := links["docs/guide.md"]; !ok || guide.Fragment != "setup" || guide.Target != filepath.Join(tmpDir, "docs/guide.md") {
+		t.Errorf("Guide link = %+v, want fragment setup resolved to docs/guide.md", guide)
+	}
+}
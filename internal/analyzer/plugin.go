@@ -0,0 +1,325 @@
+package analyzer
+
+import (
+	"encoding/json"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/pthm/cclint/internal/agent"
+)
+
+// PluginManifestDir is the directory holding plugin and marketplace manifests
+const PluginManifestDir = ".claude-plugin"
+
+// SkipDirs are directories never searched for plugins or files
+var SkipDirs = map[string]bool{
+	".git":         true,
+	"node_modules": true,
+	"vendor":       true,
+}
+
+// maxPluginSearchDepth bounds how deep FindPluginRoots looks below the project root
+const maxPluginSearchDepth = 4
+
+// Plugin describes a Claude Code plugin found in the project
+type Plugin struct {
+	// Name is the plugin name from the manifest (or the directory name)
+	Name string
+
+	// Root is the plugin directory containing .claude-plugin/
+	Root string
+
+	// ManifestPath is the path to .claude-plugin/plugin.json
+	ManifestPath string
+
+	// Manifest is the decoded plugin.json (nil if it is not valid JSON)
+	Manifest map[string]interface{}
+
+	// Scope contains the plugin's manifest, hooks and MCP config, with its
+	// namespaced commands, skills and output styles as children
+	Scope *ContextScope
+
+	// Agents are the plugin's namespaced subagent scopes
+	Agents []*ContextScope
+}
+
+// Skills returns the plugin's skill scopes
+func (p *Plugin) Skills() []*ContextScope {
+	var skills []*ContextScope
+	for _, child := range p.Scope.Children {
+		if child.Type == ScopeTypeSkill {
+			skills = append(skills, child)
+		}
+	}
+	return skills
+}
+
+// PluginManifestPath returns the plugin.json path for a plugin root
+func PluginManifestPath(pluginRoot string) string {
+	return filepath.Join(pluginRoot, PluginManifestDir, "plugin.json")
+}
+
+// MarketplaceManifestPath returns the marketplace.json path for a marketplace root
+func MarketplaceManifestPath(marketplaceRoot string) string {
+	return filepath.Join(marketplaceRoot, PluginManifestDir, "marketplace.json")
+}
+
+// FindMarketplaces returns the directories under rootPath that contain a
+// .claude-plugin/marketplace.json
+func FindMarketplaces(rootPath string) []string {
+	return findManifests(rootPath).marketplaces
+}
+
+// FindPluginRoots returns the directories under rootPath that contain a
+// .claude-plugin/plugin.json, plus plugins listed with relative sources in
+// any marketplace.json
+func FindPluginRoots(rootPath string) []string {
+	return findManifests(rootPath).plugins
+}
+
+// PluginRoots is FindPluginRoots, walking the project once per tree
+func (t *Tree) PluginRoots(rootPath string) []string {
+	return t.manifests(rootPath).plugins
+}
+
+// Marketplaces is FindMarketplaces, walking the project once per tree
+func (t *Tree) Marketplaces(rootPath string) []string {
+	return t.manifests(rootPath).marketplaces
+}
+
+// manifestRoots are the plugin and marketplace roots found under rootPath
+type manifestRoots struct {
+	rootPath     string
+	plugins      []string
+	marketplaces []string
+}
+
+// manifests returns the plugin and marketplace roots under rootPath, cached
+// on the tree
+func (t *Tree) manifests(rootPath string) *manifestRoots {
+	if t.manifestCache == nil || t.manifestCache.rootPath != rootPath {
+		t.manifestCache = findManifests(rootPath)
+	}
+	return t.manifestCache
+}
+
+// MarketplacePluginSources returns the resolved directories of plugins listed
+// with a relative path source in a marketplace
+func MarketplacePluginSources(marketplaceRoot string) []string {
+	data, err := os.ReadFile(MarketplaceManifestPath(marketplaceRoot))
+	if err != nil {
+		return nil
+	}
+
+	var manifest struct {
+		Plugins []struct {
+			Source interface{} `json:"source"`
+		} `json:"plugins"`
+	}
+	if err := json.Unmarshal(data, &manifest); err != nil {
+		return nil
+	}
+
+	var sources []string
+	for _, plugin := range manifest.Plugins {
+		if source, ok := plugin.Source.(string); ok && IsRelativePluginSource(source) {
+			sources = append(sources, filepath.Join(marketplaceRoot, source))
+		}
+	}
+	return sources
+}
+
+// IsRelativePluginSource reports whether a marketplace source is a local path
+func IsRelativePluginSource(source string) bool {
+	return strings.HasPrefix(source, "./") || strings.HasPrefix(source, "../") || source == "."
+}
+
+// findManifests walks rootPath once for .claude-plugin/plugin.json and
+// marketplace.json files, then adds the plugins marketplaces list with
+// relative sources
+func findManifests(rootPath string) *manifestRoots {
+	found := &manifestRoots{rootPath: rootPath}
+
+	_ = filepath.WalkDir(rootPath, func(path string, d fs.DirEntry, err error) error {
+		if err != nil || !d.IsDir() {
+			return nil
+		}
+		if path != rootPath && SkipDirs[d.Name()] {
+			return filepath.SkipDir
+		}
+
+		rel, _ := filepath.Rel(rootPath, path)
+		if rel != "." && strings.Count(rel, string(filepath.Separator))+1 > maxPluginSearchDepth {
+			return filepath.SkipDir
+		}
+
+		if d.Name() == PluginManifestDir {
+			root := filepath.Dir(path)
+			if _, err := os.Stat(PluginManifestPath(root)); err == nil {
+				found.plugins = append(found.plugins, root)
+			}
+			if _, err := os.Stat(MarketplaceManifestPath(root)); err == nil {
+				found.marketplaces = append(found.marketplaces, root)
+			}
+			return filepath.SkipDir
+		}
+		return nil
+	})
+
+	seen := make(map[string]bool)
+	for _, root := range found.plugins {
+		seen[root] = true
+	}
+	for _, marketplace := range found.marketplaces {
+		for _, source := range MarketplacePluginSources(marketplace) {
+			if seen[source] {
+				continue
+			}
+			if _, err := os.Stat(PluginManifestPath(source)); err == nil {
+				seen[source] = true
+				found.plugins = append(found.plugins, source)
+			}
+		}
+	}
+
+	return found
+}
+
+// DiscoverPlugins finds all plugins in the project and builds their scopes.
+// Plugin commands, skills and subagents are namespaced with the plugin name
+// (e.g. "my-plugin:deploy"), matching how Claude Code exposes them.
+func (t *Tree) DiscoverPlugins(agentConfig *agent.Config, rootPath string) ([]*Plugin, error) {
+	var plugins []*Plugin
+
+	// Load marketplace manifests so they are linted alongside everything else
+	for _, marketplace := range t.Marketplaces(rootPath) {
+		path := MarketplaceManifestPath(marketplace)
+		if _, exists := t.Nodes[path]; !exists {
+			_, _ = t.processFile(path, agentConfig, nil, 1)
+		}
+	}
+
+	for _, root := range t.PluginRoots(rootPath) {
+		plugin := t.discoverPlugin(agentConfig, root)
+		if plugin != nil {
+			plugins = append(plugins, plugin)
+		}
+	}
+
+	return plugins, nil
+}
+
+// discoverPlugin builds the scope for a single plugin root
+func (t *Tree) discoverPlugin(agentConfig *agent.Config, root string) *Plugin {
+	manifestPath := PluginManifestPath(root)
+
+	plugin := &Plugin{
+		Name:         filepath.Base(root),
+		Root:         root,
+		ManifestPath: manifestPath,
+	}
+
+	if data, err := os.ReadFile(manifestPath); err == nil {
+		if err := json.Unmarshal(data, &plugin.Manifest); err == nil {
+			if name, ok := plugin.Manifest["name"].(string); ok && name != "" {
+				plugin.Name = name
+			}
+		}
+	}
+
+	plugin.Scope = &ContextScope{
+		Type:       ScopeTypePlugin,
+		Name:       plugin.Name,
+		Entrypoint: manifestPath,
+		Plugin:     plugin.Name,
+	}
+
+	// Manifest, hooks and MCP config form the plugin scope's own files
+	configFiles := []string{manifestPath}
+	configFiles = append(configFiles, plugin.componentPaths("hooks", filepath.Join("hooks", "hooks.json"))...)
+	configFiles = append(configFiles, plugin.componentPaths("mcpServers", ".mcp.json")...)
+	for _, path := range configFiles {
+		info, err := os.Stat(path)
+		if err != nil || info.IsDir() {
+			continue
+		}
+		if _, exists := t.Nodes[path]; !exists {
+			_, _ = t.processFile(path, agentConfig, nil, 1)
+		}
+		if node, exists := t.Nodes[path]; exists {
+			plugin.Scope.Nodes = append(plugin.Scope.Nodes, node)
+			plugin.Scope.FilePaths = append(plugin.Scope.FilePaths, path)
+		}
+	}
+
+	// Commands and skills become namespaced child scopes
+	for _, dir := range plugin.componentPaths("commands", "commands") {
+		commands, _ := t.discoverCommandsIn(agentConfig, dir, plugin.Name)
+		plugin.Scope.Children = append(plugin.Scope.Children, commands...)
+	}
+	for _, dir := range plugin.componentPaths("skills", "skills") {
+		skills, _ := t.discoverSkillsIn(agentConfig, dir, plugin.Name)
+		plugin.Scope.Children = append(plugin.Scope.Children, skills...)
+	}
+	for _, dir := range plugin.componentPaths("outputStyles", "output-styles") {
+		styles, _ := t.discoverOutputStylesIn(agentConfig, dir, plugin.Name)
+		plugin.Scope.Children = append(plugin.Scope.Children, styles...)
+	}
+	for _, child := range plugin.Scope.Children {
+		child.Plugin = plugin.Name
+	}
+
+	// Subagents become namespaced top-level scopes, like project subagents
+	agentEntrypoints := make(map[string]string)
+	for _, path := range plugin.componentPaths("agents", "agents") {
+		if info, err := os.Stat(path); err == nil && !info.IsDir() {
+			agentEntrypoints[path] = strings.TrimSuffix(filepath.Base(path), ".md")
+			continue
+		}
+		discoverAgentsIn(path, agentEntrypoints)
+	}
+	for entrypoint, name := range agentEntrypoints {
+		scope := t.newFileScope(ScopeTypeSubagent, namespaced(plugin.Name, name), entrypoint, agentConfig)
+		if scope == nil {
+			continue
+		}
+		scope.Plugin = plugin.Name
+		plugin.Agents = append(plugin.Agents, scope)
+	}
+
+	return plugin
+}
+
+// componentPaths returns the locations of a plugin component: the default
+// location plus any custom paths declared in the manifest. Custom paths
+// supplement rather than replace the defaults. Inline (object) values are
+// ignored since they have no file of their own.
+func (p *Plugin) componentPaths(key, defaultPath string) []string {
+	paths := []string{filepath.Join(p.Root, defaultPath)}
+
+	if p.Manifest == nil {
+		return paths
+	}
+
+	var custom []string
+	switch v := p.Manifest[key].(type) {
+	case string:
+		custom = append(custom, v)
+	case []interface{}:
+		for _, item := range v {
+			if s, ok := item.(string); ok {
+				custom = append(custom, s)
+			}
+		}
+	}
+
+	for _, c := range custom {
+		path := filepath.Join(p.Root, c)
+		if path != paths[0] {
+			paths = append(paths, path)
+		}
+	}
+	return paths
+}
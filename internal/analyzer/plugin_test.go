@@ -0,0 +1,105 @@
+package analyzer
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/pthm/cclint/internal/agent"
+)
+
+func writeFiles(t *testing.T, root string, files map[string]string) {
+	t.Helper()
+	for name, content := range files {
+		path := filepath.Join(root, name)
+		if err := os.MkdirAll(filepath.Dir(path), 0o755); err != nil {
+			t.Fatalf("Failed to create dir for %s: %v", name, err)
+		}
+		if err := os.WriteFile(path, []byte(content), 0o644); err != nil {
+			t.Fatalf("Failed to write file %s: %v", name, err)
+		}
+	}
+}
+
+func TestDiscoverPlugins(t *testing.T) {
+	tmpDir, err := os.MkdirTemp("", "plugin-test-*")
+	if err != nil {
+		t.Fatalf("Failed to create temp dir: %v", err)
+	}
+	defer os.RemoveAll(tmpDir)
+
+	writeFiles(t, tmpDir, map[string]string{
+		".claude-plugin/marketplace.json": `{
+  "name": "internal-tools",
+  "owner": {"name": "Platform"},
+  "plugins": [{"name": "deployer", "source": "./plugins/deployer"}]
+}`,
+		"plugins/deployer/.claude-plugin/plugin.json": `{"name": "deployer", "version": "1.0.0"}`,
+		"plugins/deployer/commands/ship.md":           "# Ship\nShip the release.",
+		"plugins/deployer/skills/rollout/SKILL.md":    "---\nname: rollout\n---\n# Rollout",
+		"plugins/deployer/agents/releaser.md":         "---\nname: releaser\nskills: rollout\n---\n# Releaser",
+		"plugins/deployer/hooks/hooks.json":           `{"hooks": {}}`,
+	})
+
+	agentConfig, err := agent.Load("claude-code")
+	if err != nil {
+		t.Fatalf("Failed to load agent config: %v", err)
+	}
+
+	// A plugin-only repository has no project entrypoints but is still lintable
+	tree, err := BuildTree(tmpDir, agentConfig)
+	if err != nil {
+		t.Fatalf("Failed to build tree: %v", err)
+	}
+
+	scopes, err := tree.DiscoverScopes(agentConfig, tmpDir)
+	if err != nil {
+		t.Fatalf("DiscoverScopes failed: %v", err)
+	}
+
+	var pluginScope, agentScope *ContextScope
+	for _, scope := range scopes {
+		switch {
+		case scope.Type == ScopeTypePlugin:
+			pluginScope = scope
+		case scope.Type == ScopeTypeSubagent && scope.Name == "deployer:releaser":
+			agentScope = scope
+		}
+	}
+
+	if pluginScope == nil {
+		t.Fatalf("Expected plugin scope, got %d scopes", len(scopes))
+	}
+	if pluginScope.Name != "deployer" {
+		t.Errorf("Plugin scope name = %q, want %q", pluginScope.Name, "deployer")
+	}
+	if len(pluginScope.Nodes) != 2 {
+		t.Errorf("Expected manifest and hooks in plugin scope, got %v", pluginScope.FilePaths)
+	}
+
+	children := make(map[string]ScopeType)
+	for _, child := range pluginScope.Children {
+		children[child.Name] = child.Type
+		if child.Plugin != "deployer" {
+			t.Errorf("Child %s Plugin = %q, want %q", child.Name, child.Plugin, "deployer")
+		}
+	}
+	if children["deployer:ship"] != ScopeTypeCommand {
+		t.Errorf("Expected namespaced command deployer:ship, got %v", children)
+	}
+	if children["deployer:rollout"] != ScopeTypeSkill {
+		t.Errorf("Expected namespaced skill deployer:rollout, got %v", children)
+	}
+
+	if agentScope == nil {
+		t.Fatal("Expected namespaced subagent scope deployer:releaser")
+	}
+	if len(agentScope.Children) != 1 || agentScope.Children[0].Name != "deployer:rollout" {
+		t.Errorf("Expected plugin subagent to resolve its plugin's skill, got %v", agentScope.Children)
+	}
+
+	marketplace := filepath.Join(tmpDir, ".claude-plugin", "marketplace.json")
+	if _, ok := tree.Nodes[marketplace]; !ok {
+		t.Error("Expected marketplace.json to be loaded into the tree")
+	}
+}
@@ -0,0 +1,122 @@
+package analyzer
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+)
+
+// Resolution records how a file reference's target path was chosen
+type Resolution int
+
+const (
+	// ResolutionNone means the reference has not been resolved
+	ResolutionNone Resolution = iota
+	// ResolutionImportRelative is an import resolved against the importing file's directory
+	ResolutionImportRelative
+	// ResolutionHome is a ~/ path expanded to the user's home directory
+	ResolutionHome
+	// ResolutionAbsolute is an absolute filesystem path
+	ResolutionAbsolute
+	// ResolutionProjectRoot is a path found relative to the project root
+	ResolutionProjectRoot
+	// ResolutionSourceRelative is a path found relative to the referencing file
+	ResolutionSourceRelative
+	// ResolutionNotFound is a path that exists under none of the candidates;
+	// Target holds the location it was expected at
+	ResolutionNotFound
+)
+
+func (r Resolution) String() string {
+	switch r {
+	case ResolutionNone:
+		return "unresolved"
+	case ResolutionImportRelative:
+		return "relative to importing file"
+	case ResolutionHome:
+		return "home directory"
+	case ResolutionAbsolute:
+		return "absolute path"
+	case ResolutionProjectRoot:
+		return "relative to project root"
+	case ResolutionSourceRelative:
+		return "relative to referencing file"
+	case ResolutionNotFound:
+		return "not found"
+	default:
+		return "unknown"
+	}
+}
+
+// ResolveReference resolves a file reference to a path, reporting how the
+// path was chosen and whether it exists.
+//
+// Imports follow Claude Code's rules: relative paths resolve against the
+// importing file, ~/ expands to the home directory and absolute paths are
+// used as-is. Markdown links resolve the way GitHub renders them: relative
+// to the linking file, or to the project root for /paths. Other file
+// mentions are conventionally written relative to the project root, so the
+// root is tried before the referencing file.
+func ResolveReference(rootPath string, ref Reference) (string, Resolution, bool) {
+	if ref.Type == RefTypeLink {
+		switch {
+		case ref.Value == "":
+			return ref.Source.File, ResolutionSourceRelative, true // Same-file anchor
+		case strings.HasPrefix(ref.Value, "/"):
+			return resolvedAs(filepath.Join(rootPath, ref.Value), ResolutionProjectRoot)
+		default:
+			return resolvedAs(filepath.Join(filepath.Dir(ref.Source.File), ref.Value), ResolutionSourceRelative)
+		}
+	}
+
+	refPath := strings.TrimPrefix(ref.Value, "@")
+
+	if rest, ok := strings.CutPrefix(refPath, "~/"); ok {
+		home, err := os.UserHomeDir()
+		if err != nil {
+			return "", ResolutionNotFound, false
+		}
+		target := filepath.Join(home, rest)
+		return resolvedAs(target, ResolutionHome)
+	}
+
+	if ref.Import {
+		if filepath.IsAbs(refPath) {
+			return resolvedAs(filepath.Clean(refPath), ResolutionAbsolute)
+		}
+		target := filepath.Join(filepath.Dir(ref.Source.File), refPath)
+		return resolvedAs(target, ResolutionImportRelative)
+	}
+
+	// Mentions like `/CABLE.md` mean rootPath/CABLE.md, falling back to a
+	// real absolute path
+	rootRelative := filepath.Join(rootPath, refPath)
+	if exists(rootRelative) {
+		return rootRelative, ResolutionProjectRoot, true
+	}
+	if strings.HasPrefix(refPath, "/") {
+		if exists(refPath) {
+			return filepath.Clean(refPath), ResolutionAbsolute, true
+		}
+	} else if sourceRelative := filepath.Join(filepath.Dir(ref.Source.File), refPath); exists(sourceRelative) {
+		return sourceRelative, ResolutionSourceRelative, true
+	}
+
+	// Prefer the project-root path for error reporting since that's the
+	// expected convention
+	return rootRelative, ResolutionNotFound, false
+}
+
+// resolvedAs returns target with how, or ResolutionNotFound if it does not exist
+func resolvedAs(target string, how Resolution) (string, Resolution, bool) {
+	if !exists(target) {
+		return target, ResolutionNotFound, false
+	}
+	return target, how, true
+}
+
+// exists reports whether a path exists
+func exists(path string) bool {
+	_, err := os.Stat(path)
+	return err == nil
+}
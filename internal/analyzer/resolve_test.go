@@ -0,0 +1,97 @@
+package analyzer
+
+import (
+	"path/filepath"
+	"testing"
+)
+
+func TestResolveReference(t *testing.T) {
+	root := t.TempDir()
+	home := t.TempDir()
+	t.Setenv("HOME", home)
+
+	writeFiles(t, root, map[string]string{
+		"docs/guide.md":        "# Guide",
+		"docs/nested/local.md": "# Local",
+		"shared.md":            "# Shared",
+	})
+	writeFiles(t, home, map[string]string{
+		".claude/personal.md": "# Personal",
+	})
+
+	source := filepath.Join(root, "docs", "CLAUDE.md")
+
+	tests := []struct {
+		name       string
+		ref        Reference
+		wantTarget string
+		wantHow    Resolution
+		wantOK     bool
+	}{
+		{
+			name:       "import relative to importing file",
+			ref:        Reference{Value: "nested/local.md", Import: true},
+			wantTarget: filepath.Join(root, "docs/nested/local.md"),
+			wantHow:    ResolutionImportRelative,
+			wantOK:     true,
+		},
+		{
+			name:       "import is not resolved against the project root",
+			ref:        Reference{Value: "shared.md", Import: true},
+			wantTarget: filepath.Join(root, "docs/shared.md"),
+			wantHow:    ResolutionNotFound,
+		},
+		{
+			name:       "import from home directory",
+			ref:        Reference{Value: "~/.claude/personal.md", Import: true},
+			wantTarget: filepath.Join(home, ".claude/personal.md"),
+			wantHow:    ResolutionHome,
+			wantOK:     true,
+		},
+		{
+			name:       "absolute import",
+			ref:        Reference{Value: filepath.Join(root, "shared.md"), Import: true},
+			wantTarget: filepath.Join(root, "shared.md"),
+			wantHow:    ResolutionAbsolute,
+			wantOK:     true,
+		},
+		{
+			name:       "mention relative to project root",
+			ref:        Reference{Value: "shared.md"},
+			wantTarget: filepath.Join(root, "shared.md"),
+			wantHow:    ResolutionProjectRoot,
+			wantOK:     true,
+		},
+		{
+			name:       "mention falls back to referencing file",
+			ref:        Reference{Value: "guide.md"},
+			wantTarget: filepath.Join(root, "docs/guide.md"),
+			wantHow:    ResolutionSourceRelative,
+			wantOK:     true,
+		},
+		{
+			name:       "missing mention reported at project root",
+			ref:        Reference{Value: "/missing.md"},
+			wantTarget: filepath.Join(root, "missing.md"),
+			wantHow:    ResolutionNotFound,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tt.ref.Type = RefTypeFile
+			tt.ref.Source = Location{File: source, Line: 1, Column: 1}
+
+			target, how, ok := ResolveReference(root, tt.ref)
+			if target != tt.wantTarget {
+				t.Errorf("target = %q, want %q", target, tt.wantTarget)
+			}
+			if how != tt.wantHow {
+				t.Errorf("resolution = %s, want %s", how, tt.wantHow)
+			}
+			if ok != tt.wantOK {
+				t.Errorf("ok = %v, want %v", ok, tt.wantOK)
+			}
+		})
+	}
+}
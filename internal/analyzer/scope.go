@@ -6,6 +6,7 @@ import (
 	"strings"
 
 	"github.com/pthm/cclint/internal/agent"
+	"github.com/pthm/cclint/internal/parser"
 )
 
 // ScopeType represents the type of context scope
@@ -20,6 +21,10 @@ const (
 	ScopeTypeCommand
 	// ScopeTypeSkill represents a skill context
 	ScopeTypeSkill
+	// ScopeTypePlugin represents a Claude Code plugin and its components
+	ScopeTypePlugin
+	// ScopeTypeOutputStyle represents an output style that replaces the main system prompt
+	ScopeTypeOutputStyle
 )
 
 func (st ScopeType) String() string {
@@ -32,6 +37,10 @@ func (st ScopeType) String() string {
 		return "command"
 	case ScopeTypeSkill:
 		return "skill"
+	case ScopeTypePlugin:
+		return "plugin"
+	case ScopeTypeOutputStyle:
+		return "output-style"
 	default:
 		return "unknown"
 	}
@@ -64,13 +73,41 @@ type ContextScope struct {
 
 	// DeclaredTools contains tool names declared in frontmatter
 	DeclaredTools []string
+
+	// Plugin is the name of the plugin providing this scope (empty for project scopes)
+	Plugin string
+}
+
+// EstimatedTokens returns the estimated tokens of the files in the scope
+func (s *ContextScope) EstimatedTokens() int {
+	tokens := 0
+	for _, node := range s.Nodes {
+		tokens += EstimateTokens(node.Content)
+	}
+	return tokens
 }
 
 // DiscoverScopes finds all context scopes in the tree.
 // It identifies the main scope and any subagent scopes from:
 // 1. RefTypeSubagent references in parsed files
 // 2. Files in well-known paths like .claude/agents/
+// Plugin scopes and their namespaced subagents are appended after these (see DiscoverPlugins).
+// Scopes are discovered once per tree; later calls return the same scopes.
 func (t *Tree) DiscoverScopes(agentConfig *agent.Config, rootPath string) ([]*ContextScope, error) {
+	if t.scopeCache != nil && t.scopeRoot == rootPath {
+		return t.scopeCache, nil
+	}
+
+	scopes, err := t.discoverScopes(agentConfig, rootPath)
+	if err != nil {
+		return nil, err
+	}
+	t.scopeCache, t.scopeRoot = scopes, rootPath
+	return scopes, nil
+}
+
+// discoverScopes does the work of DiscoverScopes
+func (t *Tree) discoverScopes(agentConfig *agent.Config, rootPath string) ([]*ContextScope, error) {
 	var scopes []*ContextScope
 
 	// Collect subagent entrypoints from both sources
@@ -98,56 +135,11 @@ func (t *Tree) DiscoverScopes(agentConfig *agent.Config, rootPath string) ([]*Co
 	}
 
 	// 2. Find subagents from well-known paths
-	agentsDir := filepath.Join(rootPath, ".claude", "agents")
-	if entries, err := os.ReadDir(agentsDir); err == nil {
-		for _, entry := range entries {
-			if entry.IsDir() {
-				// Check for CLAUDE.md or instructions.md inside directory
-				dirPath := filepath.Join(agentsDir, entry.Name())
-				for _, filename := range []string{"CLAUDE.md", "instructions.md"} {
-					path := filepath.Join(dirPath, filename)
-					if _, err := os.Stat(path); err == nil {
-						if _, exists := subagentEntrypoints[path]; !exists {
-							subagentEntrypoints[path] = entry.Name()
-						}
-						break
-					}
-				}
-			} else if strings.HasSuffix(entry.Name(), ".md") {
-				// Direct .md file in agents directory
-				path := filepath.Join(agentsDir, entry.Name())
-				name := strings.TrimSuffix(entry.Name(), ".md")
-				if _, exists := subagentEntrypoints[path]; !exists {
-					subagentEntrypoints[path] = name
-				}
-			}
-		}
-	}
+	discoverAgentsIn(filepath.Join(rootPath, ".claude", "agents"), subagentEntrypoints)
 
 	// Create subagent scopes
 	for entrypoint, name := range subagentEntrypoints {
-		scope := &ContextScope{
-			Type:       ScopeTypeSubagent,
-			Name:       name,
-			Entrypoint: entrypoint,
-		}
-
-		// If entrypoint is not in tree, process it to walk its references
-		if _, exists := t.Nodes[entrypoint]; !exists {
-			// Process the subagent file and its references, adding them to the tree
-			_, _ = t.processFile(entrypoint, agentConfig, nil, 1)
-		}
-
-		// Now collect all reachable nodes from the entrypoint
-		if _, exists := t.Nodes[entrypoint]; exists {
-			scope.Nodes = t.collectReachableNodes(entrypoint)
-			scope.FilePaths = make([]string, 0, len(scope.Nodes))
-			for _, n := range scope.Nodes {
-				scope.FilePaths = append(scope.FilePaths, n.Path)
-			}
-		}
-
-		if len(scope.Nodes) > 0 {
+		if scope := t.newFileScope(ScopeTypeSubagent, name, entrypoint, agentConfig); scope != nil {
 			scopes = append(scopes, scope)
 		}
 	}
@@ -171,19 +163,29 @@ func (t *Tree) DiscoverScopes(agentConfig *agent.Config, rootPath string) ([]*Co
 		}
 	}
 
-	// Discover commands and skills
+	// Discover commands, skills and output styles
 	commands, _ := t.DiscoverCommands(agentConfig, rootPath)
 	skills, _ := t.DiscoverSkills(agentConfig, rootPath)
+	outputStyles, _ := t.DiscoverOutputStyles(agentConfig, rootPath)
 
-	// Add commands and skills as children of main scope
+	// Add commands, skills and output styles as children of main scope
 	mainScope.Children = append(mainScope.Children, commands...)
 	mainScope.Children = append(mainScope.Children, skills...)
+	mainScope.Children = append(mainScope.Children, outputStyles...)
 
 	// Main scope first, then subagents
 	if len(mainScope.Nodes) > 0 || len(mainScope.Children) > 0 {
 		scopes = append([]*ContextScope{mainScope}, scopes...)
 	}
 
+	// Plugins come last: each plugin scope followed by its namespaced subagents
+	plugins, _ := t.DiscoverPlugins(agentConfig, rootPath)
+	for _, plugin := range plugins {
+		scopes = append(scopes, plugin.Scope)
+		scopes = append(scopes, plugin.Agents...)
+		skills = append(skills, plugin.Skills()...)
+	}
+
 	// For each subagent, find and attach declared skills/tools from frontmatter
 	for _, subagentScope := range scopes {
 		if subagentScope.Type != ScopeTypeSubagent {
@@ -197,9 +199,10 @@ func (t *Tree) DiscoverScopes(agentConfig *agent.Config, rootPath string) ([]*Co
 			subagentScope.DeclaredSkills = declaredSkills
 
 			for _, skillName := range declaredSkills {
-				// Find matching skill scope and add as child
+				// Find matching skill scope and add as child.
+				// Plugin subagents may refer to their plugin's skills without the namespace.
 				for _, skill := range skills {
-					if skill.Name == skillName {
+					if skill.Name == skillName || (subagentScope.Plugin != "" && skill.Name == subagentScope.Plugin+":"+skillName) {
 						subagentScope.Children = append(subagentScope.Children, skill)
 						break
 					}
@@ -347,9 +350,14 @@ func (t *Tree) AllPaths() []string {
 // DiscoverSkills finds all skills in .claude/skills/ and builds scopes for them.
 // Each skill directory becomes its own context scope that can be analyzed independently.
 func (t *Tree) DiscoverSkills(agentConfig *agent.Config, rootPath string) ([]*ContextScope, error) {
+	return t.discoverSkillsIn(agentConfig, filepath.Join(rootPath, ".claude", "skills"), "")
+}
+
+// discoverSkillsIn builds skill scopes for a skills directory.
+// A non-empty namespace is prefixed to each skill name (e.g. "plugin:skill").
+func (t *Tree) discoverSkillsIn(agentConfig *agent.Config, skillsDir, namespace string) ([]*ContextScope, error) {
 	var skills []*ContextScope
 
-	skillsDir := filepath.Join(rootPath, ".claude", "skills")
 	if _, err := os.Stat(skillsDir); os.IsNotExist(err) {
 		return skills, nil // No skills directory, return empty
 	}
@@ -383,28 +391,7 @@ func (t *Tree) DiscoverSkills(agentConfig *agent.Config, rootPath string) ([]*Co
 			continue
 		}
 
-		// Build scope for this skill
-		scope := &ContextScope{
-			Type:       ScopeTypeSkill,
-			Name:       skillName,
-			Entrypoint: skillPath,
-		}
-
-		// Process the skill file to follow its references
-		if _, exists := t.Nodes[skillPath]; !exists {
-			_, _ = t.processFile(skillPath, agentConfig, nil, 1)
-		}
-
-		// Collect reachable nodes from skill entrypoint
-		if _, exists := t.Nodes[skillPath]; exists {
-			scope.Nodes = t.collectReachableNodes(skillPath)
-			scope.FilePaths = make([]string, 0, len(scope.Nodes))
-			for _, n := range scope.Nodes {
-				scope.FilePaths = append(scope.FilePaths, n.Path)
-			}
-		}
-
-		if len(scope.Nodes) > 0 {
+		if scope := t.newFileScope(ScopeTypeSkill, namespaced(namespace, skillName), skillPath, agentConfig); scope != nil {
 			skills = append(skills, scope)
 		}
 	}
@@ -415,9 +402,14 @@ func (t *Tree) DiscoverSkills(agentConfig *agent.Config, rootPath string) ([]*Co
 // DiscoverCommands finds all slash commands in .claude/commands/ and builds scopes for them.
 // Each command file becomes its own context scope that can be analyzed independently.
 func (t *Tree) DiscoverCommands(agentConfig *agent.Config, rootPath string) ([]*ContextScope, error) {
+	return t.discoverCommandsIn(agentConfig, filepath.Join(rootPath, ".claude", "commands"), "")
+}
+
+// discoverCommandsIn builds command scopes for a commands directory.
+// A non-empty namespace is prefixed to each command name (e.g. "plugin:command").
+func (t *Tree) discoverCommandsIn(agentConfig *agent.Config, commandsDir, namespace string) ([]*ContextScope, error) {
 	var commands []*ContextScope
 
-	commandsDir := filepath.Join(rootPath, ".claude", "commands")
 	if _, err := os.Stat(commandsDir); os.IsNotExist(err) {
 		return commands, nil // No commands directory, return empty
 	}
@@ -441,34 +433,25 @@ func (t *Tree) DiscoverCommands(agentConfig *agent.Config, rootPath string) ([]*
 		// .claude/commands/commit.md -> "commit"
 		// .claude/commands/git/push.md -> "git/push"
 		relPath, _ := filepath.Rel(commandsDir, path)
+		if relPath == "." {
+			// commandsDir is itself a single command file
+			relPath = d.Name()
+		}
 		commandName := strings.TrimSuffix(relPath, ".md")
 		commandName = strings.ReplaceAll(commandName, string(filepath.Separator), "/")
 
-		// Build scope for this command
-		scope := &ContextScope{
-			Type:       ScopeTypeCommand,
-			Name:       commandName,
-			Entrypoint: path,
-		}
-
-		// Process the command file to follow its references
-		if _, exists := t.Nodes[path]; !exists {
-			_, _ = t.processFile(path, agentConfig, nil, 1)
-		}
-
-		// Collect reachable nodes from command entrypoint
-		if _, exists := t.Nodes[path]; exists {
-			scope.Nodes = t.collectReachableNodes(path)
-			scope.FilePaths = make([]string, 0, len(scope.Nodes))
-			for _, n := range scope.Nodes {
-				scope.FilePaths = append(scope.FilePaths, n.Path)
-			}
+		scope := t.newFileScope(ScopeTypeCommand, namespaced(namespace, commandName), path, agentConfig)
+		if scope == nil {
+			return nil
 		}
 
-		if len(scope.Nodes) > 0 {
-			commands = append(commands, scope)
+		// Command files outside .claude/commands (e.g. in plugins) are not
+		// recognised by path, so mark them explicitly
+		if node := t.Nodes[path]; node.Parsed != nil && node.Parsed.Category == parser.FileCategoryUnknown {
+			node.Parsed.Category = parser.FileCategoryCommands
 		}
 
+		commands = append(commands, scope)
 		return nil
 	})
 
@@ -478,3 +461,121 @@ func (t *Tree) DiscoverCommands(agentConfig *agent.Config, rootPath string) ([]*
 
 	return commands, nil
 }
+
+// DiscoverOutputStyles finds all output styles in .claude/output-styles/ and builds scopes for them.
+// Output styles replace parts of the main agent's system prompt, so each is its own scope.
+func (t *Tree) DiscoverOutputStyles(agentConfig *agent.Config, rootPath string) ([]*ContextScope, error) {
+	return t.discoverOutputStylesIn(agentConfig, filepath.Join(rootPath, ".claude", "output-styles"), "")
+}
+
+// discoverOutputStylesIn builds output style scopes for an output styles directory.
+// The style name comes from the `name` frontmatter field, falling back to the filename.
+func (t *Tree) discoverOutputStylesIn(agentConfig *agent.Config, stylesDir, namespace string) ([]*ContextScope, error) {
+	var styles []*ContextScope
+
+	entries, err := os.ReadDir(stylesDir)
+	if os.IsNotExist(err) {
+		return styles, nil // No output styles directory, return empty
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	for _, entry := range entries {
+		if entry.IsDir() || !strings.HasSuffix(entry.Name(), ".md") {
+			continue
+		}
+
+		path := filepath.Join(stylesDir, entry.Name())
+		scope := t.newFileScope(ScopeTypeOutputStyle, "", path, agentConfig)
+		if scope == nil {
+			continue
+		}
+
+		name := strings.TrimSuffix(entry.Name(), ".md")
+		node := t.Nodes[path]
+		if node.Parsed != nil {
+			if fmName, ok := node.Parsed.Frontmatter["name"].(string); ok && fmName != "" {
+				name = fmName
+			}
+			// Output styles outside .claude/output-styles (e.g. in plugins) are
+			// not recognised by path, so mark them explicitly
+			node.Parsed.Category = parser.FileCategoryOutputStyles
+		}
+		scope.Name = namespaced(namespace, name)
+
+		styles = append(styles, scope)
+	}
+
+	return styles, nil
+}
+
+// discoverAgentsIn adds subagent definitions found in agentsDir to entrypoints (path -> name).
+// Both direct .md files and directories containing CLAUDE.md or instructions.md are recognised.
+func discoverAgentsIn(agentsDir string, entrypoints map[string]string) {
+	entries, err := os.ReadDir(agentsDir)
+	if err != nil {
+		return
+	}
+
+	for _, entry := range entries {
+		if entry.IsDir() {
+			// Check for CLAUDE.md or instructions.md inside directory
+			dirPath := filepath.Join(agentsDir, entry.Name())
+			for _, filename := range []string{"CLAUDE.md", "instructions.md"} {
+				path := filepath.Join(dirPath, filename)
+				if _, err := os.Stat(path); err == nil {
+					if _, exists := entrypoints[path]; !exists {
+						entrypoints[path] = entry.Name()
+					}
+					break
+				}
+			}
+		} else if strings.HasSuffix(entry.Name(), ".md") {
+			// Direct .md file in agents directory
+			path := filepath.Join(agentsDir, entry.Name())
+			name := strings.TrimSuffix(entry.Name(), ".md")
+			if _, exists := entrypoints[path]; !exists {
+				entrypoints[path] = name
+			}
+		}
+	}
+}
+
+// newFileScope processes an entrypoint file (following its references) and
+// builds a scope from every node reachable from it.
+// Returns nil if the entrypoint could not be loaded.
+func (t *Tree) newFileScope(scopeType ScopeType, name, entrypoint string, agentConfig *agent.Config) *ContextScope {
+	scope := &ContextScope{
+		Type:       scopeType,
+		Name:       name,
+		Entrypoint: entrypoint,
+	}
+
+	// If entrypoint is not in tree, process it to walk its references
+	if _, exists := t.Nodes[entrypoint]; !exists {
+		_, _ = t.processFile(entrypoint, agentConfig, nil, 1)
+	}
+
+	// Collect all reachable nodes from the entrypoint
+	if _, exists := t.Nodes[entrypoint]; exists {
+		scope.Nodes = t.collectReachableNodes(entrypoint)
+		scope.FilePaths = make([]string, 0, len(scope.Nodes))
+		for _, n := range scope.Nodes {
+			scope.FilePaths = append(scope.FilePaths, n.Path)
+		}
+	}
+
+	if len(scope.Nodes) == 0 {
+		return nil
+	}
+	return scope
+}
+
+// namespaced prefixes name with namespace ("plugin:name"), if set
+func namespaced(namespace, name string) string {
+	if namespace == "" {
+		return name
+	}
+	return namespace + ":" + name
+}
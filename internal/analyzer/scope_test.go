@@ -121,6 +121,15 @@ This agent writes code.`,
 	if !subagentNames["coder"] {
 		t.Error("Expected 'coder' subagent scope")
 	}
+
+	// Discovery runs once per tree
+	again, err := tree.DiscoverScopes(agentConfig, tmpDir)
+	if err != nil {
+		t.Fatalf("DiscoverScopes failed: %v", err)
+	}
+	if len(again) != len(scopes) || again[0] != scopes[0] {
+		t.Error("DiscoverScopes rediscovered scopes instead of returning the cached ones")
+	}
 }
 
 func TestDiscoverScopesWithSubagentReference(t *testing.T) {
@@ -20,6 +20,7 @@ const (
 	RefTypeSubagent
 	RefTypeSkill
 	RefTypeMCPServer
+	RefTypeLink // Markdown link or image; checked but never loaded into the tree
 	RefTypeUnknown
 )
 
@@ -37,6 +38,8 @@ func (rt RefType) String() string {
 		return "skill"
 	case RefTypeMCPServer:
 		return "mcp_server"
+	case RefTypeLink:
+		return "link"
 	default:
 		return "unknown"
 	}
@@ -78,10 +81,18 @@ type Reference struct {
 	Type     RefType
 	Value    string
 	Source   Location
-	Priority int    // Based on surrounding markers
-	Context  string // Surrounding text for context
-	Resolved bool   // Whether the reference was resolved
-	Target   string // Resolved path/URL
+	Priority int       // Based on surrounding markers
+	Context  string    // Surrounding text for context
+	Resolved bool      // Whether the reference was resolved
+	Target   string    // Resolved path/URL
+	Origin   RefOrigin // Where in the file the reference was found (prose, code, link, frontmatter)
+
+	EndColumn  int        // Column just past Value on Source.Line (0 if unknown)
+	Import     bool       // An import the agent loads into context (e.g. @path in CLAUDE.md)
+	Resolution Resolution // How Target was chosen for file references
+
+	Fragment string // #fragment of a link destination, without the #
+	Image    bool   // The link is an image
 }
 
 // ConfigNode represents a node in the configuration tree
@@ -100,6 +111,12 @@ type Tree struct {
 	Root     *ConfigNode
 	RootPath string                   // Absolute path to project root
 	Nodes    map[string]*ConfigNode // Path -> Node
+
+	// Discovery walks the project and loads files, so its results are
+	// cached for the life of the tree
+	manifestCache *manifestRoots
+	scopeCache    []*ContextScope
+	scopeRoot     string
 }
 
 // BuildTree builds a reference tree starting from the given path
@@ -124,7 +141,8 @@ func BuildTree(rootPath string, agentConfig *agent.Config) (*Tree, error) {
 		entrypoints = append(entrypoints, matches...)
 	}
 
-	if len(entrypoints) == 0 {
+	// Plugin and marketplace repositories may have no project-level entrypoints
+	if len(entrypoints) == 0 && len(tree.PluginRoots(absRoot)) == 0 && len(tree.Marketplaces(absRoot)) == 0 {
 		return nil, fmt.Errorf("no configuration files found")
 	}
 
@@ -179,31 +197,38 @@ func (t *Tree) processFile(path string, agentConfig *agent.Config, parent *Confi
 	t.Nodes[path] = node
 
 	// Extract references
-	node.References = extractReferences(content, path, agentConfig)
+	node.References = extractReferences(content, path, parsed, agentConfig)
+
+	// Resolve every file reference and link, even where the tree stops
+	// descending, so rules can see what lies past the limit
+	for i, ref := range node.References {
+		if ref.Type == RefTypeFile || ref.Type == RefTypeLink {
+			target, resolution, ok := ResolveReference(t.RootPath, ref)
+			node.References[i].Target = target
+			node.References[i].Resolution = resolution
+			node.References[i].Resolved = ok
+		}
+	}
 
-	// Process file references (don't go too deep)
-	if depth < 5 {
+	// Follow references as far as the agent follows imports: depth 1 is the
+	// entrypoint, so files up to MaxImportDepth hops away are loaded
+	if depth <= agentConfig.MaxImportDepth() {
 		seenChildren := make(map[string]bool)
-		for i, ref := range node.References {
-			if ref.Type == RefTypeFile {
-				resolvedPath := resolveFilePath(t.RootPath, path, ref.Value)
-				node.References[i].Target = resolvedPath
-
-				if _, err := os.Stat(resolvedPath); err == nil {
-					node.References[i].Resolved = true
-
-					// Avoid duplicate children from multiple references to the same file
-					if seenChildren[resolvedPath] {
-						continue
-					}
-					seenChildren[resolvedPath] = true
-
-					// Process child file
-					child, err := t.processFile(resolvedPath, agentConfig, node, depth+1)
-					if err == nil {
-						node.Children = append(node.Children, child)
-					}
-				}
+		for _, ref := range node.References {
+			if ref.Type != RefTypeFile || !ref.Resolved {
+				continue
+			}
+
+			// Avoid duplicate children from multiple references to the same file
+			if seenChildren[ref.Target] {
+				continue
+			}
+			seenChildren[ref.Target] = true
+
+			// Process child file
+			child, err := t.processFile(ref.Target, agentConfig, node, depth+1)
+			if err == nil {
+				node.Children = append(node.Children, child)
 			}
 		}
 	}
@@ -211,10 +236,13 @@ func (t *Tree) processFile(path string, agentConfig *agent.Config, parent *Confi
 	return node, nil
 }
 
-// extractReferences extracts all references from content
-func extractReferences(content []byte, path string, agentConfig *agent.Config) []Reference {
+// extractReferences extracts all references from content.
+// Markdown structure decides each reference's origin; patterns can opt out
+// of matching inside code blocks and code spans.
+func extractReferences(content []byte, path string, parsed *parser.ParsedFile, agentConfig *agent.Config) []Reference {
 	var refs []Reference
 	lines := strings.Split(string(content), "\n")
+	origins := newOriginMap(lines, parsed)
 
 	for _, pattern := range agentConfig.ReferencePatterns {
 		re := pattern.CompiledRegex()
@@ -227,31 +255,42 @@ func extractReferences(content []byte, path string, agentConfig *agent.Config) [
 		for lineNum, line := range lines {
 			matches := re.FindAllStringSubmatchIndex(line, -1)
 			for _, match := range matches {
-				if len(match) >= 4 {
-					value := line[match[2]:match[3]]
-
-					// Calculate priority based on markers
-					priority := calculatePriority(lines, lineNum, agentConfig)
-
-					// Get context
-					context := getContext(lines, lineNum, 2)
-
-					refs = append(refs, Reference{
-						Type:  refType,
-						Value: value,
-						Source: Location{
-							File:   path,
-							Line:   lineNum + 1,
-							Column: match[2] + 1,
-						},
-						Priority: priority,
-						Context:  context,
-					})
+				if len(match) < 4 || match[2] < 0 {
+					continue
+				}
+				value := line[match[2]:match[3]]
+
+				origin := origins.origin(lineNum+1, match[2], match[3])
+				if (origin == RefOriginCode && pattern.SkipCodeBlocks) || (origin == RefOriginInlineCode && pattern.SkipCodeSpans) {
+					continue
 				}
+
+				// Calculate priority based on markers
+				priority := calculatePriority(lines, lineNum, agentConfig)
+
+				// Get context
+				context := getContext(lines, lineNum, 2)
+
+				refs = append(refs, Reference{
+					Type:   refType,
+					Import: pattern.Import,
+					Value:  value,
+					Source: Location{
+						File:   path,
+						Line:   lineNum + 1,
+						Column: match[2] + 1,
+					},
+					EndColumn: match[3] + 1,
+					Priority:  priority,
+					Context:   context,
+					Origin:    origin,
+				})
 			}
 		}
 	}
 
+	refs = append(refs, linkReferences(lines, path, parsed, agentConfig)...)
+
 	return refs
 }
 
@@ -307,51 +346,6 @@ func getContext(lines []string, lineNum int, radius int) string {
 	return strings.Join(lines[start:end], "\n")
 }
 
-// resolveFilePath resolves a relative file path
-// Claude configs typically use project-root-relative paths, so we try that first
-func resolveFilePath(rootPath, sourcePath, refPath string) string {
-	// Remove @ prefix if present
-	refPath = strings.TrimPrefix(refPath, "@")
-
-	// Check if it's a project-root-relative path (starts with /)
-	// e.g., `/CABLE.md` means rootPath/CABLE.md
-	if strings.HasPrefix(refPath, "/") {
-		// Try resolving relative to project root first
-		rootRelative := filepath.Join(rootPath, refPath)
-		if _, err := os.Stat(rootRelative); err == nil {
-			return filepath.Clean(rootRelative)
-		}
-
-		// If not found at root, check if it's actually an absolute path that exists
-		if filepath.IsAbs(refPath) {
-			if _, err := os.Stat(refPath); err == nil {
-				return refPath
-			}
-		}
-
-		// Default to root-relative even if not found (for error reporting)
-		return filepath.Clean(rootRelative)
-	}
-
-	// For relative paths (no leading /), try project root first since Claude configs
-	// typically document paths relative to the project root
-	rootRelative := filepath.Join(rootPath, refPath)
-	if _, err := os.Stat(rootRelative); err == nil {
-		return filepath.Clean(rootRelative)
-	}
-
-	// Fall back to resolving relative to source file's directory
-	sourceDir := filepath.Dir(sourcePath)
-	sourceRelative := filepath.Clean(filepath.Join(sourceDir, refPath))
-	if _, err := os.Stat(sourceRelative); err == nil {
-		return sourceRelative
-	}
-
-	// Neither exists - prefer project-root-relative for error reporting
-	// since that's the expected convention
-	return rootRelative
-}
-
 // NodeCount returns the total number of nodes in the tree
 func (t *Tree) NodeCount() int {
 	return len(t.Nodes)
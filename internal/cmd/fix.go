@@ -2,10 +2,11 @@ package cmd
 
 import (
 	"fmt"
+	"os"
 	"path/filepath"
 
+	tea "github.com/charmbracelet/bubbletea"
 	"github.com/pthm/cclint/internal/agent"
-	"github.com/pthm/cclint/internal/analyzer"
 	"github.com/pthm/cclint/internal/fixer"
 	"github.com/pthm/cclint/internal/rules"
 	"github.com/pthm/cclint/internal/ui"
@@ -13,33 +14,90 @@ import (
 )
 
 var (
-	aiAssisted bool
-	dryRun     bool
+	aiAssisted   bool
+	dryRun       bool
+	interactive  bool
+	undoRun      string
+	allowOutside bool
 )
 
+// undoLatest is the --undo value when no run ID is given
+const undoLatest = "latest"
+
 var fixCmd = &cobra.Command{
 	Use:   "fix [path]",
 	Short: "Auto-fix Claude Code configuration issues",
 	Long: `Automatically fix issues in Claude Code configurations.
 
+With --dry-run, fixes are shown as a unified diff instead of being applied.
+With --interactive, each fix is shown in context and can be accepted,
+skipped, edited or accepted for every issue of the same rule; nothing is
+written until the review is finished. With --format patch, the diff is
+written to stdout as a patch that can be reviewed and applied from the
+project root with git apply.
+
+With --ai, Claude suggests rewrites for issues that have no automatic fix,
+such as vague instructions, verbosity and contradictions. Suggestions are
+checked against the current file content before they are shown or applied;
+combine --ai with --dry-run or --interactive to review them first.
+
+Every run that changes files is recorded under .cclint/fixes. --undo
+restores the files changed by the latest run, or by the given run ID,
+which may be followed by the project path; it refuses if any of those
+files changed after the fix.
+
+Fixes only write files inside the project root, after resolving symlinks.
+--allow-outside-root lifts that limit, but a symlink into another
+repository is never followed, and a patch never includes files outside the
+root.
+
 Examples:
   cclint fix .
+  cclint fix --dry-run .
+  cclint fix --interactive .
+  cclint fix --format patch . > fixes.patch
   cclint fix --ai .
-  cclint fix --ai --dry-run .`,
-	Args: cobra.MaximumNArgs(1),
+  cclint fix --ai --dry-run .
+  cclint fix --undo
+  cclint fix --undo 20261018-153045
+  cclint fix --undo 20261018-153045 ./project`,
+	Args: fixArgs,
 	RunE: runFix,
 }
 
 func init() {
-	fixCmd.Flags().BoolVar(&aiAssisted, "ai", false, "Enable AI-assisted fixes using Claude API")
+	fixCmd.Flags().BoolVar(&aiAssisted, "ai", false, "Enable AI-assisted fixes using Claude Code")
 	fixCmd.Flags().BoolVar(&dryRun, "dry-run", false, "Show fixes without applying them")
+	fixCmd.Flags().BoolVarP(&interactive, "interactive", "i", false, "Review each fix before it is applied")
+	fixCmd.Flags().StringVar(&undoRun, "undo", "", "Undo the latest fix run, or the run with the given ID")
+	fixCmd.Flags().Lookup("undo").NoOptDefVal = undoLatest
+	fixCmd.Flags().BoolVar(&allowOutside, "allow-outside-root", false, "Allow fixes to write files outside the project root")
 	RootCmd.AddCommand(fixCmd)
 }
 
+// fixArgs accepts an optional path, plus a run ID before it after a bare
+// --undo: `--undo ID` leaves the ID as a positional argument
+func fixArgs(cmd *cobra.Command, args []string) error {
+	if undoRun == undoLatest {
+		return cobra.RangeArgs(0, 2)(cmd, args)
+	}
+	return cobra.MaximumNArgs(1)(cmd, args)
+}
+
 func runFix(cmd *cobra.Command, args []string) error {
 	path := "."
 	if len(args) > 0 {
-		path = args[0]
+		path = args[len(args)-1]
+	}
+
+	if undoRun != "" {
+		if undoRun == undoLatest && (len(args) == 2 || (len(args) == 1 && fixer.IsRunID(args[0]))) {
+			undoRun = args[0]
+			if len(args) == 1 {
+				path = "."
+			}
+		}
+		return runUndo(path, undoRun)
 	}
 
 	absPath, err := filepath.Abs(path)
@@ -68,89 +126,210 @@ func runFix(cmd *cobra.Command, args []string) error {
 		return fmt.Errorf("failed to load agent config: %w", err)
 	}
 
-	// Stage 2: Build reference tree
+	linted, err := runRules(absPath, agentConfig, false, progress)
+	if err != nil {
+		return err
+	}
+	issues := linted.issues
+
+	// Stop progress before output
 	if progress != nil {
-		progress.SetStage(ui.StageBuildTree)
+		progress.Done(nil)
+		progress = nil
 	}
 
-	tree, err := analyzer.BuildTree(absPath, agentConfig)
-	if err != nil {
-		return fmt.Errorf("failed to build reference tree: %w", err)
+	// In patch mode stdout carries only the patch
+	out := os.Stdout
+	if format == "patch" {
+		out = os.Stderr
 	}
 
-	// Stage 3: Run rules to find issues
-	if progress != nil {
-		progress.SetStage(ui.StageRunRules)
+	// Create fixer
+	f := fixer.New(fixer.Options{
+		DryRun:           dryRun,
+		AIAssisted:       aiAssisted,
+		RootPath:         absPath,
+		AllowOutsideRoot: allowOutside,
+	}, u)
+
+	fixable := fixableIssues(issues, nil)
+	if aiAssisted {
+		fixable = append(fixable, aiFixes(f, issues, out)...)
+	}
+	if len(fixable) == 0 {
+		fmt.Fprintln(out, u.Styles.Success.Render(u.Styles.IconSuccess+" No fixable issues found!"))
+		return nil
 	}
 
-	registry := rules.DefaultRegistry()
-	var allIssues []rules.Issue
+	fmt.Fprintf(out, "Found %d fixable issues\n\n", len(fixable))
+
+	if format == "patch" {
+		result, err := f.Patch(fixable, os.Stdout)
+		if err != nil {
+			return fmt.Errorf("failed to write patch: %w", err)
+		}
+		for _, failed := range result.Failed {
+			fmt.Fprintln(out, u.Styles.Warning.Render(
+				fmt.Sprintf("%s Left out of the patch: %s: %v", u.Styles.IconWarning, failed.Issue.Rule, failed.Err),
+			))
+		}
+		if len(result.Conflicts) > 0 {
+			fmt.Fprintln(out, u.Styles.Warning.Render(
+				fmt.Sprintf("%s %d overlapping fixes left out of the patch; run cclint fix again after applying it", u.Styles.IconWarning, len(result.Conflicts)),
+			))
+		}
+		return nil
+	}
 
-	ctx := &rules.AnalysisContext{
-		Tree:        tree,
-		AgentConfig: agentConfig,
-		RootPath:    absPath,
+	if dryRun {
+		f.ApplyFixes(fixable)
+		return nil
 	}
 
-	ruleList := registry.Rules(false)
-	if progress != nil {
-		progress.SetRuleCount(len(ruleList))
+	if interactive {
+		return reviewFixes(f, fixable, absPath)
 	}
 
-	for _, rule := range ruleList {
-		if progress != nil {
-			progress.RuleStart(rule.Name())
+	// Apply fixes, then re-lint and fix again until nothing changes: fixes
+	// skipped for overlapping an earlier one get fresh positions each pass
+	applied := make(map[string]bool)
+	total := 0
+	for pass := 1; pass <= maxFixPasses && len(fixable) > 0; pass++ {
+		result := f.ApplyFixes(fixable)
+		if len(result.Applied) == 0 {
+			break
+		}
+		total += len(result.Applied)
+		for _, issue := range result.Applied {
+			applied[fixKey(issue)] = true
 		}
 
-		issues, err := rule.Run(ctx)
+		linted, err = runRules(absPath, agentConfig, false, nil)
 		if err != nil {
-			if progress != nil {
-				progress.RuleDone()
-			}
-			continue // Skip rules that fail
+			return err
 		}
-		allIssues = append(allIssues, issues...)
+		// A fix that reappears after being applied would loop forever
+		fixable = fixableIssues(linted.issues, applied)
+	}
 
-		if progress != nil {
-			progress.RuleDone()
-		}
+	fmt.Println()
+	fmt.Printf("Fixed %d issues\n", total)
+	printUndoHint(f)
+	if len(fixable) > 0 {
+		fmt.Println(u.Styles.Warning.Render(
+			fmt.Sprintf("%s %d fixes could not be applied; review them manually", u.Styles.IconWarning, len(fixable)),
+		))
 	}
 
-	// Stop progress before output
-	if progress != nil {
-		progress.Done(nil)
-		progress = nil
+	return nil
+}
+
+// reviewFixes lets the user review each fix in a TUI, then applies the
+// accepted ones
+func reviewFixes(f *fixer.Fixer, fixable []rules.Issue, absPath string) error {
+	u := GetUI()
+	if !u.IsInteractive() {
+		return fmt.Errorf("--interactive requires a terminal")
 	}
 
-	// Filter to fixable issues
-	var fixableIssues []rules.Issue
-	for _, issue := range allIssues {
-		if issue.Fix != nil {
-			fixableIssues = append(fixableIssues, issue)
-		}
+	model := ui.NewFixReviewModel(fixable, absPath, f.Diff)
+	final, err := tea.NewProgram(model, tea.WithAltScreen()).Run()
+	if err != nil {
+		return fmt.Errorf("error running fix review: %w", err)
+	}
+
+	review := final.(ui.FixReviewModel)
+	if review.Aborted() {
+		fmt.Println("Review aborted; no files were changed")
+		return nil
 	}
 
-	if len(fixableIssues) == 0 {
-		fmt.Println(u.Styles.Success.Render(u.Styles.IconSuccess + " No fixable issues found!"))
+	accepted := review.Accepted()
+	if len(accepted) == 0 {
+		fmt.Println("No fixes accepted; no files were changed")
 		return nil
 	}
 
-	fmt.Printf("Found %d fixable issues\n\n", len(fixableIssues))
+	result := f.ApplyFixes(accepted)
+	fmt.Println()
+	fmt.Printf("Fixed %d of %d issues\n", len(result.Applied), len(fixable))
+	printUndoHint(f)
+	return nil
+}
 
-	// Create fixer
-	f := fixer.New(fixer.Options{
-		DryRun:     dryRun,
-		AIAssisted: aiAssisted,
-	}, u)
+// printUndoHint tells the user how to undo the run, if it changed files
+func printUndoHint(f *fixer.Fixer) {
+	if id := f.RunID(); id != "" {
+		fmt.Printf("Recorded as fix run %s; undo with: cclint fix --undo %s\n", id, id)
+	}
+}
+
+// runUndo restores the files changed by a recorded fix run
+func runUndo(path, id string) error {
+	absPath, err := filepath.Abs(path)
+	if err != nil {
+		return fmt.Errorf("invalid path: %w", err)
+	}
+	if id == undoLatest {
+		id = ""
+	}
+
+	journal, err := fixer.Undo(absPath, id)
+	if err != nil {
+		return err
+	}
+
+	u := GetUI()
+	for _, entry := range journal.Files {
+		fmt.Println(u.Styles.Success.Render(
+			fmt.Sprintf("%s Restored: %s", u.Styles.IconSuccess, entry.Path),
+		))
+	}
+	fmt.Println()
+	fmt.Printf("Undid fix run %s (%d files)\n", journal.ID, len(journal.Files))
+	return nil
+}
 
-	// Apply fixes
-	for _, issue := range fixableIssues {
-		if err := f.ApplyFix(issue); err != nil {
-			fmt.Println(u.Styles.Error.Render(
-				fmt.Sprintf("%s Failed to fix %s: %v", u.Styles.IconError, issue.Rule, err),
+// aiFixes asks Claude for fixes to issues that have no deterministic fix.
+// Suggestions that fail validation are reported and left out.
+func aiFixes(f *fixer.Fixer, issues []rules.Issue, out *os.File) []rules.Issue {
+	u := GetUI()
+
+	var fixed []rules.Issue
+	for _, issue := range issues {
+		if !fixer.AIFixable(issue) {
+			continue
+		}
+		fmt.Fprintf(out, "Generating AI fix for %s in %s...\n", issue.Rule, issue.File)
+		fix, err := f.AIFix(issue)
+		if err != nil {
+			fmt.Fprintln(out, u.Styles.Warning.Render(
+				fmt.Sprintf("%s No AI fix for %s: %v", u.Styles.IconWarning, issue.Rule, err),
 			))
+			continue
 		}
+		issue.Fix = fix
+		fixed = append(fixed, issue)
 	}
+	return fixed
+}
 
-	return nil
+// maxFixPasses bounds how many lint-and-fix passes the fix command runs
+const maxFixPasses = 10
+
+// fixableIssues returns the issues with edits to apply, excluding any whose
+// fix was already applied
+func fixableIssues(issues []rules.Issue, applied map[string]bool) []rules.Issue {
+	var fixable []rules.Issue
+	for _, issue := range issues {
+		if issue.Fix != nil && len(issue.Fix.Edits) > 0 && !applied[fixKey(issue)] {
+			fixable = append(fixable, issue)
+		}
+	}
+	return fixable
+}
+
+// fixKey identifies an issue's fix across lint passes
+func fixKey(issue rules.Issue) string {
+	return fmt.Sprintf("%s|%s|%s|%s", issue.Rule, issue.File, issue.Message, issue.Fix.Description)
 }
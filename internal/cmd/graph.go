@@ -144,6 +144,12 @@ func printScope(scope *analyzer.ContextScope, tree *analyzer.Tree, rootPath stri
 	case analyzer.ScopeTypeSkill:
 		icon = "🔧"
 		label = fmt.Sprintf("[%s] %s", scope.Type.String(), scope.Name)
+	case analyzer.ScopeTypePlugin:
+		icon = "🧩"
+		label = fmt.Sprintf("[%s] %s", scope.Type.String(), scope.Name)
+	case analyzer.ScopeTypeOutputStyle:
+		icon = "🎨"
+		label = fmt.Sprintf("[%s] %s", scope.Type.String(), scope.Name)
 	}
 
 	// Print scope header
@@ -183,6 +189,14 @@ func printScope(scope *analyzer.ContextScope, tree *analyzer.Tree, rootPath stri
 		printScope(childScope, tree, rootPath, childPrefix, isLastChild)
 	}
 
+	// For plugin scopes, print the manifest, hooks and MCP config files
+	if scope.Type == analyzer.ScopeTypePlugin {
+		for i, node := range scope.Nodes {
+			printFileNode(node, rootPath, childPrefix, i == len(scope.Nodes)-1)
+		}
+		return
+	}
+
 	// For non-main scopes with an entrypoint, print the file tree starting from entrypoint
 	if scope.Type != analyzer.ScopeTypeMain && scope.Entrypoint != "" {
 		if entryNode, exists := tree.Nodes[scope.Entrypoint]; exists {
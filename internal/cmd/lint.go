@@ -4,11 +4,10 @@ import (
 	"fmt"
 	"os"
 	"path/filepath"
+	"time"
 
 	"github.com/pthm/cclint/internal/agent"
-	"github.com/pthm/cclint/internal/analyzer"
 	"github.com/pthm/cclint/internal/reporter"
-	"github.com/pthm/cclint/internal/rules"
 	"github.com/pthm/cclint/internal/ui"
 	"github.com/spf13/cobra"
 )
@@ -26,7 +25,11 @@ var lintCmd = &cobra.Command{
 Examples:
   cclint lint .
   cclint lint --deep .
-  cclint lint --format json . > report.json`,
+  cclint lint --format json . > report.json
+  cclint lint --format sarif . > cclint.sarif
+  cclint lint --format github .
+  cclint lint --format junit . > cclint-junit.xml
+  cclint lint --format markdown . > comment.md`,
 	Args:         cobra.MaximumNArgs(1),
 	RunE:         runLint,
 	SilenceUsage: true,
@@ -39,6 +42,8 @@ func init() {
 }
 
 func runLint(cmd *cobra.Command, args []string) error {
+	start := time.Now()
+
 	path := "."
 	if len(args) > 0 {
 		path = args[0]
@@ -75,63 +80,10 @@ func runLint(cmd *cobra.Command, args []string) error {
 		fmt.Printf("Path: %s\n\n", absPath)
 	}
 
-	// Stage 2: Build reference tree
-	if progress != nil {
-		progress.SetStage(ui.StageBuildTree)
-	}
-
-	tree, err := analyzer.BuildTree(absPath, agentConfig)
-	if err != nil {
-		return fmt.Errorf("failed to build reference tree: %w", err)
-	}
-
-	if verbose {
-		fmt.Printf("Found %d config files\n", tree.NodeCount())
-	}
-
-	// Stage 3: Run rules
-	if progress != nil {
-		progress.SetStage(ui.StageRunRules)
-	}
-
-	registry := rules.DefaultRegistry()
-	var allIssues []rules.Issue
-
-	ctx := &rules.AnalysisContext{
-		Tree:        tree,
-		AgentConfig: agentConfig,
-		RootPath:    absPath,
-	}
-
 	// Include AI rules only when --deep is set and not offline
-	includeAI := deep && !offline
-	ruleList := registry.Rules(includeAI)
-
-	if progress != nil {
-		progress.SetRuleCount(len(ruleList))
-	}
-
-	for _, rule := range ruleList {
-		if progress != nil {
-			progress.RuleStart(rule.Name())
-		}
-
-		issues, err := rule.Run(ctx)
-		if err != nil {
-			// Use styled warning output
-			fmt.Fprintln(os.Stderr, u.Styles.Warning.Render(
-				fmt.Sprintf("%s Warning: rule %s failed: %v", u.Styles.IconWarning, rule.Name(), err),
-			))
-			if progress != nil {
-				progress.RuleDone()
-			}
-			continue
-		}
-		allIssues = append(allIssues, issues...)
-
-		if progress != nil {
-			progress.RuleDone()
-		}
+	result, err := runRules(absPath, agentConfig, deep && !offline, progress)
+	if err != nil {
+		return err
 	}
 
 	// Stop progress before reporting
@@ -140,14 +92,47 @@ func runLint(cmd *cobra.Command, args []string) error {
 		progress = nil // Prevent double-done in defer
 	}
 
+	if verbose {
+		fmt.Printf("Found %d config files\n", result.tree.NodeCount())
+	}
+
 	// Stage 4: Report results
+	data := reporter.ReportData{
+		RootPath:    absPath,
+		AgentConfig: agentConfig,
+		Scopes:      result.scopes,
+		Rules:       result.runs,
+		Duration:    time.Since(start),
+	}
+
 	var rep reporter.Reporter
 	switch format {
 	case "json":
-		rep = reporter.NewJSONReporter(os.Stdout)
+		rep = reporter.NewJSONReporter(os.Stdout, data)
+	case "sarif":
+		rep = reporter.NewSARIFReporter(os.Stdout, absPath, result.rules)
+	case "github":
+		rep = reporter.NewGitHubReporter(os.Stdout, workDir(absPath))
+	case "checkstyle":
+		rep = reporter.NewCheckstyleReporter(os.Stdout, workDir(absPath))
+	case "junit":
+		rep = reporter.NewJUnitReporter(os.Stdout, workDir(absPath))
+	case "markdown":
+		rep = reporter.NewMarkdownReporter(os.Stdout, data)
+	case "html":
+		rep = reporter.NewHTMLReporter(os.Stdout, data)
 	default:
 		rep = reporter.NewTerminalReporter(os.Stdout, u)
 	}
 
-	return rep.Report(allIssues)
+	return rep.Report(result.issues)
+}
+
+// workDir returns the working directory CI reporters make paths relative
+// to, falling back to the lint root
+func workDir(fallback string) string {
+	if wd, err := os.Getwd(); err == nil {
+		return wd
+	}
+	return fallback
 }
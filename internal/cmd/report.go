@@ -2,10 +2,13 @@ package cmd
 
 import (
 	"fmt"
+	"os"
 	"path/filepath"
+	"sort"
 
 	"github.com/pthm/cclint/internal/agent"
 	"github.com/pthm/cclint/internal/analyzer"
+	"github.com/pthm/cclint/internal/reporter"
 	"github.com/spf13/cobra"
 )
 
@@ -19,11 +22,16 @@ This includes:
   - Token usage estimates
   - Quality metrics
 
+With --format markdown or --format html, the report also lints the project
+and includes the issues, per-scope token budgets and the scope tree: markdown
+for pull request comments, HTML as a self-contained page.
+
 Use 'cclint graph --print' to see the configuration tree.
 
 Examples:
   cclint report .
-  cclint report --format json . > report.json`,
+  cclint report --format markdown . > comment.md
+  cclint report --format html . > report.html`,
 	Args: cobra.MaximumNArgs(1),
 	RunE: runReport,
 }
@@ -58,6 +66,17 @@ func runReport(cmd *cobra.Command, args []string) error {
 		return fmt.Errorf("failed to load agent config: %w", err)
 	}
 
+	if format == "markdown" || format == "html" {
+		result, err := runRules(absPath, agentConfig, false, nil)
+		if spinner != nil {
+			spinner.Stop()
+		}
+		if err != nil {
+			return err
+		}
+		return writeDocumentReport(result, agentConfig, absPath)
+	}
+
 	// Build reference tree
 	tree, err := analyzer.BuildTree(absPath, agentConfig)
 	if err != nil {
@@ -67,6 +86,14 @@ func runReport(cmd *cobra.Command, args []string) error {
 		return fmt.Errorf("failed to build reference tree: %w", err)
 	}
 
+	// Discover scopes so commands, skills, output styles and plugins are counted
+	if _, err := tree.DiscoverScopes(agentConfig, absPath); err != nil {
+		if spinner != nil {
+			spinner.Stop()
+		}
+		return fmt.Errorf("failed to discover scopes: %w", err)
+	}
+
 	// Stop spinner
 	if spinner != nil {
 		spinner.Stop()
@@ -89,11 +116,38 @@ func runReport(cmd *cobra.Command, args []string) error {
 	fmt.Printf("  Total bytes:      %d\n", metrics.TotalBytes)
 	fmt.Println()
 
+	// Print token estimate per file category
+	fmt.Println(u.Styles.Warning.Render("Estimated Tokens by Category:"))
+	for _, category := range sortedKeys(metrics.TokensByCategory) {
+		fmt.Printf("  %s: %d\n", category, metrics.TokensByCategory[category])
+	}
+	fmt.Println()
+
 	// Print reference summary
 	fmt.Println(u.Styles.Warning.Render("References by Type:"))
-	for refType, count := range metrics.ReferencesByType {
-		fmt.Printf("  %s: %d\n", refType, count)
+	for _, refType := range sortedKeys(metrics.ReferencesByType) {
+		fmt.Printf("  %s: %d\n", refType, metrics.ReferencesByType[refType])
 	}
 
 	return nil
 }
+
+// sortedKeys returns the keys of m in order, so reports are stable across runs
+func sortedKeys(m map[string]int) []string {
+	keys := make([]string, 0, len(m))
+	for key := range m {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	return keys
+}
+
+// writeDocumentReport writes a markdown or HTML report of the issues the
+// non-AI rules found and of the scopes
+func writeDocumentReport(result *ruleResults, agentConfig *agent.Config, absPath string) error {
+	data := reporter.ReportData{RootPath: absPath, AgentConfig: agentConfig, Scopes: result.scopes}
+	if format == "html" {
+		return reporter.NewHTMLReporter(os.Stdout, data).Report(result.issues)
+	}
+	return reporter.NewMarkdownReporter(os.Stdout, data).Report(result.issues)
+}
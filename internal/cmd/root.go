@@ -54,7 +54,7 @@ references, circular dependencies, and unclear instructions.`,
 func init() {
 	RootCmd.SetVersionTemplate(fmt.Sprintf("%s\n", version.Info()))
 	RootCmd.PersistentFlags().BoolVarP(&verbose, "verbose", "v", false, "Enable verbose output")
-	RootCmd.PersistentFlags().StringVarP(&format, "format", "f", "terminal", "Output format (terminal, json)")
+	RootCmd.PersistentFlags().StringVarP(&format, "format", "f", "terminal", "Output format (terminal, json, sarif, github, checkstyle, junit, markdown, html; patch for fix)")
 	RootCmd.PersistentFlags().StringVarP(&agentType, "agent", "a", "claude-code", "Agent type to lint for")
 	RootCmd.PersistentFlags().BoolVar(&noUpdateCheck, "no-update-check", false, "Disable update check")
 }
@@ -0,0 +1,102 @@
+package cmd
+
+import (
+	"fmt"
+	"os"
+	"time"
+
+	"github.com/pthm/cclint/internal/agent"
+	"github.com/pthm/cclint/internal/analyzer"
+	"github.com/pthm/cclint/internal/config"
+	"github.com/pthm/cclint/internal/reporter"
+	"github.com/pthm/cclint/internal/rules"
+	"github.com/pthm/cclint/internal/ui"
+)
+
+// ruleResults is the outcome of running the rules over a project
+type ruleResults struct {
+	tree   *analyzer.Tree
+	scopes []*analyzer.ContextScope
+	rules  []rules.Rule
+	runs   []reporter.RuleRun
+	issues []rules.Issue
+}
+
+// runRules loads the project config, builds the reference tree and runs the
+// rules over it, warning about rules that fail. AI rules run only when
+// includeAI is set. progress may be nil.
+func runRules(absPath string, agentConfig *agent.Config, includeAI bool, progress *ui.ProgressController) (*ruleResults, error) {
+	projectConfig, err := config.Load(absPath)
+	if err != nil {
+		return nil, fmt.Errorf("failed to load project config: %w", err)
+	}
+
+	// Stage 2: Build reference tree
+	if progress != nil {
+		progress.SetStage(ui.StageBuildTree)
+	}
+
+	tree, err := analyzer.BuildTree(absPath, agentConfig)
+	if err != nil {
+		return nil, fmt.Errorf("failed to build reference tree: %w", err)
+	}
+
+	// Discover scopes up front so commands, skills, subagents and plugins are
+	// loaded into the tree before any rule runs
+	scopes, err := tree.DiscoverScopes(agentConfig, absPath)
+	if err != nil {
+		return nil, fmt.Errorf("failed to discover scopes: %w", err)
+	}
+
+	// Stage 3: Run rules
+	if progress != nil {
+		progress.SetStage(ui.StageRunRules)
+	}
+
+	registry := rules.DefaultRegistry()
+	result := &ruleResults{
+		tree:   tree,
+		scopes: scopes,
+		rules:  registry.Rules(includeAI),
+	}
+
+	ctx := &rules.AnalysisContext{
+		Tree:        tree,
+		AgentConfig: agentConfig,
+		RootPath:    absPath,
+		Project:     projectConfig,
+	}
+
+	if progress != nil {
+		progress.SetRuleCount(len(result.rules))
+	}
+
+	u := GetUI()
+	for _, rule := range result.rules {
+		if progress != nil {
+			progress.RuleStart(rule.Name())
+		}
+
+		ruleStart := time.Now()
+		issues, err := rule.Run(ctx)
+		result.runs = append(result.runs, reporter.RuleRun{
+			Rule:     rule,
+			Category: registry.Category(rule.Name()),
+			Duration: time.Since(ruleStart),
+			Err:      err,
+		})
+		if err != nil {
+			fmt.Fprintln(os.Stderr, u.Styles.Warning.Render(
+				fmt.Sprintf("%s Warning: rule %s failed: %v", u.Styles.IconWarning, rule.Name(), err),
+			))
+		} else {
+			result.issues = append(result.issues, issues...)
+		}
+
+		if progress != nil {
+			progress.RuleDone()
+		}
+	}
+
+	return result, nil
+}
@@ -0,0 +1,86 @@
+package config
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+
+	"gopkg.in/yaml.v3"
+)
+
+// FileNames are the project config files cclint looks for, in order
+var FileNames = []string{".cclint.yaml", ".cclint.yml"}
+
+// Project holds project-level cclint configuration loaded from .cclint.yaml
+type Project struct {
+	// Path is the config file this was loaded from (empty if none was found)
+	Path string `yaml:"-"`
+
+	// RiskyInstructions extends or trims the risky-instructions policy packs
+	RiskyInstructions RiskyInstructions `yaml:"risky_instructions"`
+
+	// Secrets configures the secrets rule
+	Secrets Secrets `yaml:"secrets"`
+}
+
+// Secrets configures the secrets rule
+type Secrets struct {
+	// Allowlist contains values (or substrings of values) that are known test
+	// fixtures and should never be reported
+	Allowlist []string `yaml:"allowlist"`
+}
+
+// RiskyInstructions configures the risky-instructions rule
+type RiskyInstructions struct {
+	// Disable lists pattern IDs or pack names to turn off
+	Disable []string `yaml:"disable"`
+
+	// Packs are additional pattern packs to check
+	Packs []PatternPack `yaml:"packs"`
+}
+
+// PatternPack is a named group of policy patterns
+type PatternPack struct {
+	Name        string          `yaml:"name"`
+	Description string          `yaml:"description"`
+	Patterns    []PolicyPattern `yaml:"patterns"`
+}
+
+// PolicyPattern describes a single instruction that violates policy
+type PolicyPattern struct {
+	// ID uniquely identifies the pattern and becomes the sub-rule name
+	ID string `yaml:"id"`
+
+	// Regex matches the offending instruction text
+	Regex string `yaml:"regex"`
+
+	// Severity is one of info, suggestion, warning or error
+	Severity string `yaml:"severity"`
+
+	// Rationale explains why the instruction is risky
+	Rationale string `yaml:"rationale"`
+}
+
+// Load reads the project config from rootPath.
+// Returns an empty config if no config file exists.
+func Load(rootPath string) (*Project, error) {
+	for _, name := range FileNames {
+		path := filepath.Join(rootPath, name)
+		data, err := os.ReadFile(path)
+		if os.IsNotExist(err) {
+			continue
+		}
+		if err != nil {
+			return nil, err
+		}
+
+		var cfg Project
+		if err := yaml.Unmarshal(data, &cfg); err != nil {
+			return nil, fmt.Errorf("invalid %s: %w", name, err)
+		}
+		cfg.Path = path
+		return &cfg, nil
+	}
+
+	return &Project{}, nil
+}
@@ -0,0 +1,174 @@
+package fixer
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"os"
+	"strings"
+
+	"github.com/pthm/cclint/internal/rules"
+)
+
+// Querier sends a prompt to an LLM and returns its response text.
+// rules.LLMRuleBase implements it using Claude Code.
+type Querier interface {
+	ExecuteQuery(ctx context.Context, prompt string, cwd string) (string, error)
+}
+
+// aiFixableRules are the rules whose issues have no deterministic fix but
+// can be fixed by rewriting the affected text
+var aiFixableRules = map[string]bool{
+	"vague-instructions": true,
+	"verbosity":          true,
+	"contradictions":     true,
+}
+
+// AIFixable reports whether an issue can be given an AI-generated fix
+func AIFixable(issue rules.Issue) bool {
+	if issue.Fix != nil {
+		return false
+	}
+	rule, _, _ := strings.Cut(issue.Rule, "/")
+	return aiFixableRules[rule]
+}
+
+// aiFixResponse is the JSON structure the model is asked to return
+type aiFixResponse struct {
+	Description string `json:"description"`
+	Edits       []struct {
+		StartLine   int    `json:"start_line"`
+		EndLine     int    `json:"end_line"`
+		Original    string `json:"original"`
+		Replacement string `json:"replacement"`
+	} `json:"edits"`
+}
+
+// AIFix generates an AI-assisted fix using Claude. The suggested edits are
+// checked against the file's current content, so a fix is only returned if
+// it applies cleanly.
+func (f *Fixer) AIFix(issue rules.Issue) (*rules.Fix, error) {
+	if !f.opts.AIAssisted {
+		return nil, fmt.Errorf("AI-assisted fixes not enabled")
+	}
+	if !AIFixable(issue) {
+		return nil, fmt.Errorf("no AI fix available for %s", issue.Rule)
+	}
+
+	if f.opts.Querier == nil {
+		base := rules.NewLLMRuleBase()
+		if base == nil {
+			return nil, fmt.Errorf("AI-assisted fixes require the Claude Code CLI")
+		}
+		f.opts.Querier = base
+	}
+
+	if err := f.guard().check(issue.File); err != nil {
+		return nil, err
+	}
+
+	content, err := os.ReadFile(issue.File)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read %s: %w", issue.File, err)
+	}
+
+	response, err := f.opts.Querier.ExecuteQuery(context.Background(), aiFixPrompt(issue, f.displayPath(issue.File), string(content)), "")
+	if err != nil {
+		return nil, err
+	}
+
+	var parsed aiFixResponse
+	text := rules.ExtractJSON(response)
+	if err := json.Unmarshal([]byte(text), &parsed); err != nil {
+		return nil, fmt.Errorf("failed to parse AI fix: %w (response: %s)", err, rules.TruncateForError(text))
+	}
+
+	return validateAIFix(issue, string(content), parsed)
+}
+
+// validateAIFix converts a model response to a fix, rejecting edits that
+// don't match the file: wrong line numbers, text that isn't there, or
+// edits that overlap
+func validateAIFix(issue rules.Issue, content string, parsed aiFixResponse) (*rules.Fix, error) {
+	if len(parsed.Edits) == 0 {
+		return nil, fmt.Errorf("no fix suggested")
+	}
+
+	lines := strings.Split(content, "\n")
+	fix := &rules.Fix{Description: parsed.Description}
+	if fix.Description == "" {
+		fix.Description = "AI-suggested rewrite"
+	}
+
+	for _, e := range parsed.Edits {
+		if e.EndLine == 0 {
+			e.EndLine = e.StartLine
+		}
+		if e.StartLine < 1 || e.EndLine < e.StartLine || e.EndLine > len(lines) {
+			return nil, fmt.Errorf("suggested edit has invalid lines %d-%d", e.StartLine, e.EndLine)
+		}
+		current := strings.Join(lines[e.StartLine-1:e.EndLine], "\n")
+		if strings.TrimRight(e.Original, "\n") != current {
+			return nil, fmt.Errorf("suggested edit for lines %d-%d does not match the file", e.StartLine, e.EndLine)
+		}
+		replacement := strings.TrimRight(e.Replacement, "\n")
+		if replacement == current {
+			continue
+		}
+		fix.Edits = append(fix.Edits, rules.Edit{
+			File:       issue.File,
+			StartLine:  e.StartLine,
+			EndLine:    e.EndLine,
+			NewContent: replacement,
+		})
+	}
+	if len(fix.Edits) == 0 {
+		return nil, fmt.Errorf("suggested fix makes no changes")
+	}
+
+	// Reuse the batch planner to reject overlapping edits
+	issue.Fix = fix
+	_, result := plan([]rules.Issue{issue}, nil)
+	if len(result.Failed) > 0 {
+		return nil, result.Failed[0].Err
+	}
+	if len(result.Conflicts) > 0 {
+		return nil, fmt.Errorf("suggested edits overlap")
+	}
+
+	return fix, nil
+}
+
+// aiFixPrompt builds the prompt asking for a fix, with the file's lines
+// numbered so edits can refer to them
+func aiFixPrompt(issue rules.Issue, name, content string) string {
+	var numbered strings.Builder
+	for i, line := range strings.Split(strings.TrimSuffix(content, "\n"), "\n") {
+		fmt.Fprintf(&numbered, "%d: %s\n", i+1, line)
+	}
+
+	return fmt.Sprintf(`You are fixing a lint issue in an AI agent configuration file.
+
+File: %s
+Issue (%s, line %d): %s
+
+Rewrite only the lines needed to resolve the issue. Keep the author's intent,
+formatting and markdown structure; do not add new instructions.
+
+File content with line numbers:
+%s
+Respond with this exact JSON structure (no other text, just JSON):
+{
+  "description": "one sentence describing the change",
+  "edits": [
+    {
+      "start_line": 1,
+      "end_line": 1,
+      "original": "the exact current text of lines start_line..end_line, without line numbers",
+      "replacement": "the new text for those lines"
+    }
+  ]
+}
+
+Return ONLY valid JSON, no markdown code blocks, no explanatory text.`, name, issue.Rule, issue.Line, issue.Message, numbered.String())
+}
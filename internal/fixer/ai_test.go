@@ -0,0 +1,137 @@
+package fixer
+
+import (
+	"context"
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/pthm/cclint/internal/rules"
+	"github.com/pthm/cclint/internal/ui"
+)
+
+// fakeQuerier returns a canned response and records the prompt
+type fakeQuerier struct {
+	response string
+	prompt   string
+}
+
+func (q *fakeQuerier) ExecuteQuery(_ context.Context, prompt string, _ string) (string, error) {
+	q.prompt = prompt
+	return q.response, nil
+}
+
+func TestAIFix(t *testing.T) {
+	dir := t.TempDir()
+	path := filepath.Join(dir, "CLAUDE.md")
+	content := "# Rules\nHandle errors as appropriate.\nUse tabs.\n"
+	if err := os.WriteFile(path, []byte(content), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	issue := rules.Issue{
+		Rule:    "vague-instructions/vague-condition",
+		Message: "Vague condition: specify concrete criteria",
+		File:    path,
+		Line:    2,
+	}
+
+	tests := []struct {
+		name     string
+		response string
+		want     string // Expected new content, or "" if an error is expected
+	}{
+		{
+			name:     "valid edit",
+			response: `{"description": "Make condition concrete", "edits": [{"start_line": 2, "end_line": 2, "original": "Handle errors as appropriate.", "replacement": "Return errors to the caller with context."}]}`,
+			want:     "# Rules\nReturn errors to the caller with context.\nUse tabs.\n",
+		},
+		{
+			name:     "wrapped in markdown",
+			response: "```json\n{\"edits\": [{\"start_line\": 2, \"original\": \"Handle errors as appropriate.\", \"replacement\": \"Wrap errors.\"}]}\n```",
+			want:     "# Rules\nWrap errors.\nUse tabs.\n",
+		},
+		{
+			name:     "original does not match",
+			response: `{"edits": [{"start_line": 3, "end_line": 3, "original": "Handle errors as appropriate.", "replacement": "x"}]}`,
+		},
+		{
+			name:     "line out of range",
+			response: `{"edits": [{"start_line": 9, "end_line": 9, "original": "", "replacement": "x"}]}`,
+		},
+		{
+			name:     "overlapping edits",
+			response: `{"edits": [{"start_line": 2, "end_line": 3, "original": "Handle errors as appropriate.\nUse tabs.", "replacement": "x"}, {"start_line": 3, "end_line": 3, "original": "Use tabs.", "replacement": "y"}]}`,
+		},
+		{
+			name:     "no changes",
+			response: `{"edits": [{"start_line": 2, "end_line": 2, "original": "Handle errors as appropriate.", "replacement": "Handle errors as appropriate."}]}`,
+		},
+		{
+			name:     "no edits",
+			response: `{"edits": []}`,
+		},
+		{
+			name:     "not json",
+			response: "I can't help with that.",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			q := &fakeQuerier{response: tt.response}
+			f := New(Options{AIAssisted: true, Querier: q, RootPath: dir}, ui.New(io.Discard, io.Discard, "text"))
+
+			fix, err := f.AIFix(issue)
+			if tt.want == "" {
+				if err == nil {
+					t.Fatalf("expected an error, got fix %+v", fix)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			if !strings.Contains(q.prompt, "2: Handle errors as appropriate.") || !strings.Contains(q.prompt, "File: CLAUDE.md") {
+				t.Errorf("prompt lacks numbered file content:\n%s", q.prompt)
+			}
+
+			got := content
+			for _, edit := range fix.Edits {
+				if got, err = editContent(got, edit); err != nil {
+					t.Fatal(err)
+				}
+			}
+			if got != tt.want {
+				t.Errorf("content = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestAIFixable(t *testing.T) {
+	tests := []struct {
+		issue rules.Issue
+		want  bool
+	}{
+		{rules.Issue{Rule: "vague-instructions/vague-guidance"}, true},
+		{rules.Issue{Rule: "verbosity/long-sentences"}, true},
+		{rules.Issue{Rule: "contradictions/always-never"}, true},
+		{rules.Issue{Rule: "broken-refs"}, false},
+		{rules.Issue{Rule: "verbosity/long-sentences", Fix: &rules.Fix{}}, false},
+	}
+	for _, tt := range tests {
+		if got := AIFixable(tt.issue); got != tt.want {
+			t.Errorf("AIFixable(%s) = %v, want %v", tt.issue.Rule, got, tt.want)
+		}
+	}
+}
+
+func TestAIFixDisabled(t *testing.T) {
+	f := New(Options{Querier: &fakeQuerier{}}, ui.New(io.Discard, io.Discard, "text"))
+	if _, err := f.AIFix(rules.Issue{Rule: "verbosity/long-sentences"}); err == nil {
+		t.Error("expected an error when AI fixes are disabled")
+	}
+}
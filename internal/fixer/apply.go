@@ -0,0 +1,247 @@
+package fixer
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+
+	"github.com/pthm/cclint/internal/rules"
+)
+
+// Result summarizes a batch of fixes
+type Result struct {
+	// Applied are the issues whose fixes were written (or would be, in a dry run)
+	Applied []rules.Issue
+
+	// Conflicts are issues skipped because an edit overlaps an earlier fix;
+	// re-linting after the applied fixes usually makes them applicable
+	Conflicts []rules.Issue
+
+	// Failed are issues whose fixes could not be applied
+	Failed []FailedFix
+
+	// Files are the files that changed
+	Files []string
+}
+
+// FailedFix is a fix that could not be applied
+type FailedFix struct {
+	Issue rules.Issue
+	Err   error
+}
+
+// span is an edit resolved to a byte range of the file's current content
+type span struct {
+	start, end int
+	text       string
+}
+
+// overlaps reports whether two spans touch the same text. Insertions at the
+// same offset conflict too, since their order would be ambiguous.
+func (s span) overlaps(o span) bool {
+	if s == o {
+		return false // Identical edits are duplicates, not conflicts
+	}
+	return (s.start < o.end && o.start < s.end) || s.start == o.start
+}
+
+// fileEdits are the accepted spans for one file
+type fileEdits struct {
+	content string
+	lines   []string
+	spans   []span
+}
+
+// plan decides which fixes to apply. Each issue's edits are accepted or
+// rejected together; an issue is rejected if any edit overlaps an edit of an
+// issue accepted before it. A fix fails if guard refuses one of its files;
+// a nil guard allows every file.
+func plan(issues []rules.Issue, guard *pathGuard) (map[string]*fileEdits, *Result) {
+	result := &Result{}
+	files := make(map[string]*fileEdits)
+
+	for _, issue := range issues {
+		if issue.Fix == nil || len(issue.Fix.Edits) == 0 {
+			continue
+		}
+
+		pending := make(map[string][]span)
+		var err error
+		for _, edit := range issue.Fix.Edits {
+			fe, ok := files[edit.File]
+			if !ok {
+				if err = guard.check(edit.File); err != nil {
+					break
+				}
+				content, readErr := os.ReadFile(edit.File)
+				if readErr != nil {
+					err = readErr
+					break
+				}
+				fe = &fileEdits{content: string(content), lines: strings.Split(string(content), "\n")}
+				files[edit.File] = fe
+			}
+
+			var s span
+			if s, err = toSpan(fe, edit); err != nil {
+				break
+			}
+			pending[edit.File] = append(pending[edit.File], s)
+		}
+		if err != nil {
+			result.Failed = append(result.Failed, FailedFix{Issue: issue, Err: err})
+			continue
+		}
+
+		if conflicts(files, pending) {
+			result.Conflicts = append(result.Conflicts, issue)
+			continue
+		}
+
+		for file, spans := range pending {
+			files[file].spans = appendUnique(files[file].spans, spans...)
+		}
+		result.Applied = append(result.Applied, issue)
+	}
+
+	return files, result
+}
+
+// conflicts reports whether any pending span overlaps an accepted one, or
+// another pending span of the same issue
+func conflicts(files map[string]*fileEdits, pending map[string][]span) bool {
+	for file, spans := range pending {
+		for i, s := range spans {
+			for _, accepted := range files[file].spans {
+				if s.overlaps(accepted) {
+					return true
+				}
+			}
+			for _, other := range spans[i+1:] {
+				if s.overlaps(other) {
+					return true
+				}
+			}
+		}
+	}
+	return false
+}
+
+// appendUnique appends spans that are not already present
+func appendUnique(spans []span, add ...span) []span {
+	for _, s := range add {
+		dup := false
+		for _, existing := range spans {
+			if existing == s {
+				dup = true
+				break
+			}
+		}
+		if !dup {
+			spans = append(spans, s)
+		}
+	}
+	return spans
+}
+
+// toSpan converts an edit to a byte range of the file content
+func toSpan(fe *fileEdits, edit rules.Edit) (span, error) {
+	lines := fe.lines
+
+	// Validate line numbers
+	if edit.StartLine < 1 || edit.StartLine > len(lines) {
+		return span{}, fmt.Errorf("invalid start line: %d", edit.StartLine)
+	}
+	if edit.EndLine < edit.StartLine || edit.EndLine > len(lines) {
+		edit.EndLine = edit.StartLine
+	}
+
+	if edit.IsRange() {
+		startLine, endLine := lines[edit.StartLine-1], lines[edit.EndLine-1]
+		if edit.StartColumn > len(startLine)+1 {
+			return span{}, fmt.Errorf("invalid start column: %d", edit.StartColumn)
+		}
+		if edit.EndColumn < 1 || edit.EndColumn > len(endLine)+1 ||
+			(edit.EndLine == edit.StartLine && edit.EndColumn < edit.StartColumn) {
+			return span{}, fmt.Errorf("invalid end column: %d", edit.EndColumn)
+		}
+		return span{
+			start: lineOffset(lines, edit.StartLine) + edit.StartColumn - 1,
+			end:   lineOffset(lines, edit.EndLine) + edit.EndColumn - 1,
+			text:  edit.NewContent,
+		}, nil
+	}
+
+	start := lineOffset(lines, edit.StartLine)
+	end := lineOffset(lines, edit.EndLine) + len(lines[edit.EndLine-1])
+	if edit.NewContent == "" {
+		// Deleting lines removes their line breaks too
+		switch {
+		case edit.EndLine < len(lines):
+			end++
+		case start > 0:
+			start--
+		}
+	}
+	return span{start: start, end: end, text: edit.NewContent}, nil
+}
+
+// lineOffset returns the byte offset at which a 1-based line starts
+func lineOffset(lines []string, line int) int {
+	offset := 0
+	for _, l := range lines[:line-1] {
+		offset += len(l) + 1
+	}
+	return offset
+}
+
+// apply returns the content with the accepted spans applied bottom-up, so
+// earlier edits are not shifted by later ones
+func (fe *fileEdits) apply() string {
+	spans := append([]span(nil), fe.spans...)
+	sort.Slice(spans, func(i, j int) bool {
+		return spans[i].start > spans[j].start
+	})
+
+	content := fe.content
+	for _, s := range spans {
+		content = content[:s.start] + s.text + content[s.end:]
+	}
+	return content
+}
+
+// writeAtomic replaces a file's content via a temporary file and rename,
+// keeping the original file mode. A symlink is written through, so the link
+// itself is kept.
+func writeAtomic(path string, content []byte) error {
+	if real, err := filepath.EvalSymlinks(path); err == nil {
+		path = real
+	}
+
+	info, err := os.Stat(path)
+	if err != nil {
+		return err
+	}
+
+	tmp, err := os.CreateTemp(filepath.Dir(path), "."+filepath.Base(path)+".cclint-*")
+	if err != nil {
+		return err
+	}
+	tmpPath := tmp.Name()
+	defer os.Remove(tmpPath) // No-op once renamed
+
+	if _, err := tmp.Write(content); err != nil {
+		tmp.Close()
+		return err
+	}
+	if err := tmp.Chmod(info.Mode().Perm()); err != nil {
+		tmp.Close()
+		return err
+	}
+	if err := tmp.Close(); err != nil {
+		return err
+	}
+	return os.Rename(tmpPath, path)
+}
@@ -0,0 +1,129 @@
+package fixer
+
+import (
+	"io"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/pthm/cclint/internal/rules"
+	"github.com/pthm/cclint/internal/ui"
+)
+
+func fixIssue(rule string, edits ...rules.Edit) rules.Issue {
+	return rules.Issue{Rule: rule, Fix: &rules.Fix{Description: rule, Edits: edits}}
+}
+
+func TestApplyFixes(t *testing.T) {
+	dir := t.TempDir()
+	path := filepath.Join(dir, "CLAUDE.md")
+	content := "# Title\nRead @docs/guid.md and @docs/setp.md\nremove me\nlast line\n"
+	if err := os.WriteFile(path, []byte(content), 0o640); err != nil {
+		t.Fatal(err)
+	}
+
+	issues := []rules.Issue{
+		// Two token fixes on one line must not shift each other
+		fixIssue("a", rules.ReplaceText(path, 2, 7, 19, "docs/guide.md")),
+		fixIssue("b", rules.ReplaceText(path, 2, 25, 37, "docs/setup.md")),
+		// Deleting a line above later edits
+		fixIssue("c", rules.Edit{File: path, StartLine: 3, EndLine: 3}),
+		// Overlaps fix a
+		fixIssue("conflict", rules.ReplaceText(path, 2, 12, 16, "x")),
+		// Identical to fix b: a duplicate, not a conflict
+		fixIssue("duplicate", rules.ReplaceText(path, 2, 25, 37, "docs/setup.md")),
+		// Invalid line
+		fixIssue("invalid", rules.ReplaceText(path, 99, 1, 1, "x")),
+	}
+
+	f := New(Options{}, ui.New(io.Discard, io.Discard, "text"))
+	result := f.ApplyFixes(issues)
+
+	got, err := os.ReadFile(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	want := "# Title\nRead @docs/guide.md and @docs/setup.md\nlast line\n"
+	if string(got) != want {
+		t.Errorf("content = %q, want %q", got, want)
+	}
+
+	if len(result.Applied) != 4 {
+		t.Errorf("Applied %d fixes, want 4", len(result.Applied))
+	}
+	if len(result.Conflicts) != 1 || result.Conflicts[0].Rule != "conflict" {
+		t.Errorf("Conflicts = %+v, want the overlapping fix", result.Conflicts)
+	}
+	if len(result.Failed) != 1 || result.Failed[0].Issue.Rule != "invalid" {
+		t.Errorf("Failed = %+v, want the invalid fix", result.Failed)
+	}
+	if len(result.Files) != 1 || result.Files[0] != path {
+		t.Errorf("Files = %v, want [%s]", result.Files, path)
+	}
+
+	info, err := os.Stat(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if info.Mode().Perm() != 0o640 {
+		t.Errorf("mode = %v, want 0640", info.Mode().Perm())
+	}
+
+	// No temporary files are left behind
+	entries, err := os.ReadDir(dir)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(entries) != 1 {
+		t.Errorf("Expected only CLAUDE.md in %s, got %d entries", dir, len(entries))
+	}
+}
+
+func TestApplyFixesDryRun(t *testing.T) {
+	dir := t.TempDir()
+	path := filepath.Join(dir, "CLAUDE.md")
+	content := "Read @docs/guid.md\n"
+	if err := os.WriteFile(path, []byte(content), 0o644); err != nil {
+		t.Fatal(err)
+	}
+
+	f := New(Options{DryRun: true}, ui.New(io.Discard, io.Discard, "text"))
+	result := f.ApplyFixes([]rules.Issue{fixIssue("a", rules.ReplaceText(path, 1, 7, 19, "docs/guide.md"))})
+
+	if len(result.Applied) != 1 {
+		t.Errorf("Applied %d fixes, want 1", len(result.Applied))
+	}
+	got, _ := os.ReadFile(path)
+	if string(got) != content {
+		t.Errorf("Dry run modified the file: %q", got)
+	}
+}
+
+func TestDiff(t *testing.T) {
+	dir := t.TempDir()
+	path := filepath.Join(dir, "CLAUDE.md")
+	content := "# Title\nRead @docs/guid.md\n"
+	if err := os.WriteFile(path, []byte(content), 0o644); err != nil {
+		t.Fatal(err)
+	}
+
+	f := New(Options{RootPath: dir}, ui.New(io.Discard, io.Discard, "text"))
+	diff, err := f.Diff(fixIssue("a", rules.ReplaceText(path, 2, 7, 19, "docs/guide.md")))
+	if err != nil {
+		t.Fatal(err)
+	}
+	want := "diff --git a/CLAUDE.md b/CLAUDE.md\n--- a/CLAUDE.md\n+++ b/CLAUDE.md\n" +
+		"@@ -1,2 +1,2 @@\n # Title\n-Read @docs/guid.md\n+Read @docs/guide.md\n"
+	if diff != want {
+		t.Errorf("diff = %q, want %q", diff, want)
+	}
+
+	// Diff never writes
+	if got, _ := os.ReadFile(path); string(got) != content {
+		t.Errorf("file changed to %q", got)
+	}
+
+	if _, err := f.Diff(fixIssue("invalid", rules.ReplaceText(path, 99, 1, 1, "x"))); err == nil {
+		t.Error("expected an error for an invalid edit")
+	}
+}
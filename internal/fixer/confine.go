@@ -0,0 +1,90 @@
+package fixer
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+)
+
+// pathGuard decides which files fixes may write. Paths are compared after
+// resolving symlinks, so a link inside the project cannot redirect a write
+// elsewhere.
+type pathGuard struct {
+	root         string
+	realRoot     string
+	allowOutside bool
+}
+
+// newPathGuard creates a guard confining writes to root. An empty root
+// allows every file.
+func newPathGuard(root string, allowOutside bool) *pathGuard {
+	if root == "" {
+		return nil
+	}
+	realRoot := root
+	if real, err := filepath.EvalSymlinks(root); err == nil {
+		realRoot = real
+	}
+	return &pathGuard{root: root, realRoot: realRoot, allowOutside: allowOutside}
+}
+
+// check returns an error if a fix may not write path
+func (g *pathGuard) check(path string) error {
+	if g == nil {
+		return nil
+	}
+
+	real, err := filepath.EvalSymlinks(path)
+	if err != nil {
+		return err
+	}
+
+	// Where path would be if it were not a symlink
+	direct := filepath.Clean(path)
+	inside := within(g.root, direct)
+	if inside {
+		rel, _ := filepath.Rel(g.root, direct)
+		direct = filepath.Join(g.realRoot, rel)
+	}
+	linked := real != direct
+
+	if !within(g.realRoot, real) && !g.allowOutside {
+		if inside {
+			return fmt.Errorf("%s is a symlink to %s, outside the project root", path, real)
+		}
+		return fmt.Errorf("%s is outside the project root (use --allow-outside-root to fix it)", path)
+	}
+
+	// Even when writes outside the root are allowed, a symlink must not lead
+	// into a different repository
+	if linked && repoRoot(filepath.Dir(real)) != repoRoot(g.realRoot) {
+		return fmt.Errorf("%s is a symlink into another repository (%s)", path, real)
+	}
+
+	return nil
+}
+
+// within reports whether path is root or inside it
+func within(root, path string) bool {
+	rel, err := filepath.Rel(root, path)
+	if err != nil {
+		return false
+	}
+	return rel == "." || (rel != ".." && !strings.HasPrefix(rel, ".."+string(filepath.Separator)))
+}
+
+// repoRoot returns the nearest directory at or above dir containing .git,
+// or "" if there is none
+func repoRoot(dir string) string {
+	for {
+		if _, err := os.Stat(filepath.Join(dir, ".git")); err == nil {
+			return dir
+		}
+		parent := filepath.Dir(dir)
+		if parent == dir {
+			return ""
+		}
+		dir = parent
+	}
+}
@@ -0,0 +1,149 @@
+package fixer
+
+import (
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/pthm/cclint/internal/rules"
+	"github.com/pthm/cclint/internal/ui"
+)
+
+func TestPathGuard(t *testing.T) {
+	base := t.TempDir()
+	root := filepath.Join(base, "project")
+	outside := filepath.Join(base, "outside")
+	otherRepo := filepath.Join(base, "other")
+	for _, dir := range []string{root, outside, filepath.Join(otherRepo, ".git")} {
+		if err := os.MkdirAll(dir, 0o755); err != nil {
+			t.Fatal(err)
+		}
+	}
+	for _, path := range []string{
+		filepath.Join(root, "CLAUDE.md"),
+		filepath.Join(outside, "CLAUDE.md"),
+		filepath.Join(otherRepo, "CLAUDE.md"),
+	} {
+		if err := os.WriteFile(path, []byte("# Title\n"), 0o644); err != nil {
+			t.Fatal(err)
+		}
+	}
+	links := map[string]string{
+		"inside.md":  filepath.Join(root, "CLAUDE.md"),
+		"outside.md": filepath.Join(outside, "CLAUDE.md"),
+		"other.md":   filepath.Join(otherRepo, "CLAUDE.md"),
+	}
+	for name, target := range links {
+		if err := os.Symlink(target, filepath.Join(root, name)); err != nil {
+			t.Skipf("symlinks not supported: %v", err)
+		}
+	}
+
+	tests := []struct {
+		path         string
+		allowOutside bool
+		wantErr      string
+	}{
+		{filepath.Join(root, "CLAUDE.md"), false, ""},
+		{filepath.Join(root, "inside.md"), false, ""},
+		{filepath.Join(root, "missing.md"), false, "no such file"},
+		{filepath.Join(outside, "CLAUDE.md"), false, "outside the project root"},
+		{filepath.Join(outside, "CLAUDE.md"), true, ""},
+		{filepath.Join(root, "outside.md"), false, "is a symlink to"},
+		{filepath.Join(root, "outside.md"), true, ""},
+		{filepath.Join(root, "other.md"), false, "is a symlink to"},
+		{filepath.Join(root, "other.md"), true, "another repository"},
+		{filepath.Join(otherRepo, "CLAUDE.md"), true, ""},
+	}
+	for _, tt := range tests {
+		err := newPathGuard(root, tt.allowOutside).check(tt.path)
+		switch {
+		case tt.wantErr == "" && err != nil:
+			t.Errorf("check(%s, allow=%v) = %v, want nil", tt.path, tt.allowOutside, err)
+		case tt.wantErr != "" && (err == nil || !strings.Contains(err.Error(), tt.wantErr)):
+			t.Errorf("check(%s, allow=%v) = %v, want error containing %q", tt.path, tt.allowOutside, err, tt.wantErr)
+		}
+	}
+
+	if err := newPathGuard("", false).check(filepath.Join(outside, "CLAUDE.md")); err != nil {
+		t.Errorf("guard without a root refused a write: %v", err)
+	}
+}
+
+func TestApplyFixesConfined(t *testing.T) {
+	base := t.TempDir()
+	root := filepath.Join(base, "project")
+	if err := os.MkdirAll(root, 0o755); err != nil {
+		t.Fatal(err)
+	}
+	target := filepath.Join(root, "AGENTS.md")
+	outside := filepath.Join(base, "CLAUDE.md")
+	for _, path := range []string{target, outside} {
+		if err := os.WriteFile(path, []byte("# Title\n"), 0o644); err != nil {
+			t.Fatal(err)
+		}
+	}
+	link := filepath.Join(root, "CLAUDE.md")
+	if err := os.Symlink("AGENTS.md", link); err != nil {
+		t.Skipf("symlinks not supported: %v", err)
+	}
+
+	f := New(Options{RootPath: root}, ui.New(io.Discard, io.Discard, "text"))
+	result := f.ApplyFixes([]rules.Issue{
+		fixIssue("link", rules.ReplaceText(link, 1, 3, 8, "Rules")),
+		fixIssue("outside", rules.ReplaceText(outside, 1, 3, 8, "Rules")),
+	})
+	if len(result.Applied) != 1 || len(result.Failed) != 1 || result.Failed[0].Issue.Rule != "outside" {
+		t.Fatalf("applied %d, failed %+v", len(result.Applied), result.Failed)
+	}
+
+	// The write goes through the link, which is kept
+	if info, err := os.Lstat(link); err != nil || info.Mode()&os.ModeSymlink == 0 {
+		t.Errorf("symlink was replaced: %v", err)
+	}
+	if got, _ := os.ReadFile(target); string(got) != "# Rules\n" {
+		t.Errorf("target content = %q", got)
+	}
+	if got, _ := os.ReadFile(outside); string(got) != "# Title\n" {
+		t.Errorf("file outside the root was changed to %q", got)
+	}
+}
+
+func TestPatchLeavesOutOutsideRoot(t *testing.T) {
+	base := t.TempDir()
+	root := filepath.Join(base, "project")
+	if err := os.MkdirAll(root, 0o755); err != nil {
+		t.Fatal(err)
+	}
+	inside := filepath.Join(root, "CLAUDE.md")
+	outside := filepath.Join(base, "CLAUDE.md")
+	for _, path := range []string{inside, outside} {
+		if err := os.WriteFile(path, []byte("# Title\n"), 0o644); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	// Even when writes outside the root are allowed, git apply cannot
+	// patch those files
+	f := New(Options{RootPath: root, AllowOutsideRoot: true}, ui.New(io.Discard, io.Discard, "text"))
+	var patch strings.Builder
+	result, err := f.Patch([]rules.Issue{
+		fixIssue("inside", rules.ReplaceText(inside, 1, 3, 8, "Rules")),
+		fixIssue("outside", rules.ReplaceText(outside, 1, 3, 8, "Rules")),
+	}, &patch)
+	if err != nil {
+		t.Fatalf("Patch() error: %v", err)
+	}
+
+	if len(result.Applied) != 1 || len(result.Failed) != 1 || result.Failed[0].Issue.Rule != "outside" {
+		t.Fatalf("applied %d, failed %+v", len(result.Applied), result.Failed)
+	}
+	if !strings.HasPrefix(patch.String(), "diff --git a/CLAUDE.md b/CLAUDE.md\n") {
+		t.Errorf("patch = %q, want only the file inside the root", patch.String())
+	}
+	if strings.Contains(patch.String(), base) {
+		t.Errorf("patch mentions an absolute path: %q", patch.String())
+	}
+}
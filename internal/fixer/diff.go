@@ -0,0 +1,208 @@
+package fixer
+
+import (
+	"fmt"
+	"strings"
+)
+
+// diffContext is the number of unchanged lines shown around each change
+const diffContext = 3
+
+// opKind is a line operation in a diff
+type opKind int
+
+const (
+	opEqual opKind = iota
+	opDelete
+	opInsert
+)
+
+// diffOp is one line of a diff
+type diffOp struct {
+	kind opKind
+	line string
+}
+
+// UnifiedDiff returns a unified diff between two versions of a file, in the
+// form `git apply` accepts. name is the path shown in the a/ and b/ headers.
+// It returns "" when the contents are equal.
+func UnifiedDiff(name, oldContent, newContent string) string {
+	if oldContent == newContent {
+		return ""
+	}
+
+	ops := diffLines(splitLines(oldContent), splitLines(newContent))
+
+	var sb strings.Builder
+	fmt.Fprintf(&sb, "diff --git a/%s b/%s\n", name, name)
+	fmt.Fprintf(&sb, "--- a/%s\n", name)
+	fmt.Fprintf(&sb, "+++ b/%s\n", name)
+
+	for _, h := range hunks(ops) {
+		fmt.Fprintf(&sb, "@@ -%s +%s @@\n", hunkRange(h.oldStart, h.oldCount), hunkRange(h.newStart, h.newCount))
+		for _, op := range ops[h.from:h.to] {
+			prefix := " "
+			switch op.kind {
+			case opDelete:
+				prefix = "-"
+			case opInsert:
+				prefix = "+"
+			}
+			line, noEOL := strings.CutSuffix(op.line, noEOLMarker)
+			sb.WriteString(prefix + line + "\n")
+			if noEOL {
+				sb.WriteString("\\ No newline at end of file\n")
+			}
+		}
+	}
+
+	return sb.String()
+}
+
+// noEOLMarker is appended to a final line that has no trailing newline, so
+// it differs from the same text with a newline
+const noEOLMarker = "\x00"
+
+// splitLines splits content into lines, marking a final line without a
+// trailing newline
+func splitLines(content string) []string {
+	if content == "" {
+		return nil
+	}
+	lines := strings.Split(strings.TrimSuffix(content, "\n"), "\n")
+	if !strings.HasSuffix(content, "\n") {
+		lines[len(lines)-1] += noEOLMarker
+	}
+	return lines
+}
+
+// diffLines computes a line diff using the longest common subsequence of
+// the lines between the common prefix and suffix
+func diffLines(a, b []string) []diffOp {
+	prefix := 0
+	for prefix < len(a) && prefix < len(b) && a[prefix] == b[prefix] {
+		prefix++
+	}
+	suffix := 0
+	for suffix < len(a)-prefix && suffix < len(b)-prefix && a[len(a)-1-suffix] == b[len(b)-1-suffix] {
+		suffix++
+	}
+
+	var ops []diffOp
+	for _, line := range a[:prefix] {
+		ops = append(ops, diffOp{opEqual, line})
+	}
+
+	midA, midB := a[prefix:len(a)-suffix], b[prefix:len(b)-suffix]
+
+	// lcs[i][j] is the LCS length of midA[i:] and midB[j:]
+	lcs := make([][]int, len(midA)+1)
+	for i := range lcs {
+		lcs[i] = make([]int, len(midB)+1)
+	}
+	for i := len(midA) - 1; i >= 0; i-- {
+		for j := len(midB) - 1; j >= 0; j-- {
+			if midA[i] == midB[j] {
+				lcs[i][j] = lcs[i+1][j+1] + 1
+			} else {
+				lcs[i][j] = max(lcs[i+1][j], lcs[i][j+1])
+			}
+		}
+	}
+
+	i, j := 0, 0
+	for i < len(midA) || j < len(midB) {
+		switch {
+		case i < len(midA) && j < len(midB) && midA[i] == midB[j]:
+			ops = append(ops, diffOp{opEqual, midA[i]})
+			i++
+			j++
+		case j < len(midB) && (i == len(midA) || lcs[i][j+1] > lcs[i+1][j]):
+			ops = append(ops, diffOp{opInsert, midB[j]})
+			j++
+		default:
+			ops = append(ops, diffOp{opDelete, midA[i]})
+			i++
+		}
+	}
+
+	for _, line := range a[len(a)-suffix:] {
+		ops = append(ops, diffOp{opEqual, line})
+	}
+	return ops
+}
+
+// hunk is a range of ops shown together
+type hunk struct {
+	from, to           int // Op indexes
+	oldStart, oldCount int
+	newStart, newCount int
+}
+
+// hunks groups changes with diffContext lines of surrounding context,
+// merging changes whose context would overlap
+func hunks(ops []diffOp) []hunk {
+	var result []hunk
+	oldLine, newLine := 1, 1
+	oldAt := make([]int, len(ops)) // Line numbers before each op
+	newAt := make([]int, len(ops))
+	for i, op := range ops {
+		oldAt[i], newAt[i] = oldLine, newLine
+		if op.kind != opInsert {
+			oldLine++
+		}
+		if op.kind != opDelete {
+			newLine++
+		}
+	}
+
+	for i := 0; i < len(ops); {
+		if ops[i].kind == opEqual {
+			i++
+			continue
+		}
+
+		from := max(0, i-diffContext)
+		// Extend through changes separated by at most 2*diffContext equal lines
+		end := i
+		for end < len(ops) {
+			if ops[end].kind != opEqual {
+				end++
+				continue
+			}
+			run := end
+			for run < len(ops) && ops[run].kind == opEqual {
+				run++
+			}
+			if run == len(ops) || run-end > 2*diffContext {
+				break
+			}
+			end = run
+		}
+		to := min(len(ops), end+diffContext)
+
+		h := hunk{from: from, to: to, oldStart: oldAt[from], newStart: newAt[from]}
+		for _, op := range ops[from:to] {
+			if op.kind != opInsert {
+				h.oldCount++
+			}
+			if op.kind != opDelete {
+				h.newCount++
+			}
+		}
+		result = append(result, h)
+		i = to
+	}
+	return result
+}
+
+// hunkRange formats a hunk header range; empty ranges start one line before
+func hunkRange(start, count int) string {
+	if count == 0 {
+		return fmt.Sprintf("%d,0", start-1)
+	}
+	if count == 1 {
+		return fmt.Sprintf("%d", start)
+	}
+	return fmt.Sprintf("%d,%d", start, count)
+}
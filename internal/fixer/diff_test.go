@@ -0,0 +1,92 @@
+package fixer
+
+import (
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func TestUnifiedDiff(t *testing.T) {
+	tests := []struct {
+		name     string
+		old, new string
+		want     string
+	}{
+		{
+			name: "equal",
+			old:  "a\nb\n",
+			new:  "a\nb\n",
+			want: "",
+		},
+		{
+			name: "change with context",
+			old:  "1\n2\n3\n4\n5\n6\n7\n8\n",
+			new:  "1\n2\n3\n4\nfive\n6\n7\n8\n",
+			want: "diff --git a/f.md b/f.md\n--- a/f.md\n+++ b/f.md\n" +
+				"@@ -2,7 +2,7 @@\n 2\n 3\n 4\n-5\n+five\n 6\n 7\n 8\n",
+		},
+		{
+			name: "separate hunks",
+			old:  "a\n1\n2\n3\n4\n5\n6\n7\n8\nb\n",
+			new:  "A\n1\n2\n3\n4\n5\n6\n7\n8\nB\n",
+			want: "diff --git a/f.md b/f.md\n--- a/f.md\n+++ b/f.md\n" +
+				"@@ -1,4 +1,4 @@\n-a\n+A\n 1\n 2\n 3\n" +
+				"@@ -7,4 +7,4 @@\n 6\n 7\n 8\n-b\n+B\n",
+		},
+		{
+			name: "insert into empty file",
+			old:  "",
+			new:  "x\n",
+			want: "diff --git a/f.md b/f.md\n--- a/f.md\n+++ b/f.md\n@@ -0,0 +1 @@\n+x\n",
+		},
+		{
+			name: "missing trailing newline",
+			old:  "a\nb",
+			new:  "a\nb\n",
+			want: "diff --git a/f.md b/f.md\n--- a/f.md\n+++ b/f.md\n" +
+				"@@ -1,2 +1,2 @@\n a\n-b\n\\ No newline at end of file\n+b\n",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := UnifiedDiff("f.md", tt.old, tt.new); got != tt.want {
+				t.Errorf("UnifiedDiff() =\n%s\nwant\n%s", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestUnifiedDiffGitApply(t *testing.T) {
+	if _, err := exec.LookPath("git"); err != nil {
+		t.Skip("git not installed")
+	}
+
+	dir := t.TempDir()
+	oldContent := "# Title\n\nkeep\nremove me\nkeep\n" + strings.Repeat("filler\n", 10) + "tail"
+	newContent := "# Title\n\nkeep\nkeep\nadded\n" + strings.Repeat("filler\n", 10) + "tail changed\n"
+
+	if err := os.WriteFile(filepath.Join(dir, "CLAUDE.md"), []byte(oldContent), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	patch := filepath.Join(dir, "fix.patch")
+	if err := os.WriteFile(patch, []byte(UnifiedDiff("CLAUDE.md", oldContent, newContent)), 0o644); err != nil {
+		t.Fatal(err)
+	}
+
+	cmd := exec.Command("git", "apply", patch)
+	cmd.Dir = dir
+	if out, err := cmd.CombinedOutput(); err != nil {
+		t.Fatalf("git apply failed: %v: %s", err, out)
+	}
+
+	got, err := os.ReadFile(filepath.Join(dir, "CLAUDE.md"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if string(got) != newContent {
+		t.Errorf("patched content = %q, want %q", got, newContent)
+	}
+}
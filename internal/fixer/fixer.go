@@ -2,7 +2,9 @@ package fixer
 
 import (
 	"fmt"
-	"os"
+	"io"
+	"path/filepath"
+	"sort"
 	"strings"
 
 	"github.com/pthm/cclint/internal/rules"
@@ -13,12 +15,25 @@ import (
 type Options struct {
 	DryRun     bool
 	AIAssisted bool
+
+	// Querier answers AI fix prompts; Claude Code is used when nil
+	Querier Querier
+
+	// RootPath is the project root; diff paths are shown relative to it,
+	// and fixes may only write files inside it
+	RootPath string
+
+	// AllowOutsideRoot permits fixes to files outside RootPath
+	AllowOutsideRoot bool
 }
 
 // Fixer applies fixes to configuration files
 type Fixer struct {
 	opts Options
 	ui   *ui.UI
+
+	// journal records the files written by this run so it can be undone
+	journal *Journal
 }
 
 // New creates a new Fixer
@@ -32,98 +47,219 @@ func (f *Fixer) ApplyFix(issue rules.Issue) error {
 		return fmt.Errorf("no fix available for this issue")
 	}
 
-	fix := issue.Fix
+	result := f.ApplyFixes([]rules.Issue{issue})
+	if len(result.Failed) > 0 {
+		return result.Failed[0].Err
+	}
+	if len(result.Conflicts) > 0 {
+		return fmt.Errorf("fix has overlapping edits")
+	}
+	return nil
+}
+
+// ApplyFixes applies the fixes for a batch of issues. Edits are grouped per
+// file and applied bottom-up so they don't shift each other; a fix whose
+// edits overlap an earlier fix is skipped and reported as a conflict. Each
+// changed file is written once, atomically.
+func (f *Fixer) ApplyFixes(issues []rules.Issue) *Result {
+	files, result := plan(issues, f.guard())
 
 	if f.opts.DryRun {
-		f.printDryRun(issue, fix)
-		return nil
+		for _, issue := range result.Applied {
+			f.printDryRun(issue, issue.Fix)
+		}
+		f.printSkipped(result)
+		f.printDiffs(files)
+		return result
 	}
 
-	// Apply each edit
-	for _, edit := range fix.Edits {
-		if err := f.applyEdit(edit); err != nil {
-			return fmt.Errorf("failed to apply edit to %s: %w", edit.File, err)
+	paths := changedPaths(files)
+
+	failedFiles := make(map[string]error)
+	for _, path := range paths {
+		fixed := []byte(files[path].apply())
+		if err := writeAtomic(path, fixed); err != nil {
+			failedFiles[path] = err
+			continue
+		}
+		result.Files = append(result.Files, path)
+		f.record(path, []byte(files[path].content), fixed)
+	}
+	if len(result.Files) > 0 && f.journal != nil {
+		if err := f.journal.save(f.opts.RootPath); err != nil {
+			fmt.Println(f.ui.Styles.Warning.Render(
+				fmt.Sprintf("%s Could not record fix run for undo: %v", f.ui.Styles.IconWarning, err),
+			))
 		}
 	}
 
-	fmt.Println(f.ui.Styles.Success.Render(
-		fmt.Sprintf("%s Fixed: %s", f.ui.Styles.IconSuccess, issue.Rule),
-	))
-	fmt.Printf("  %s\n", fix.Description)
+	// Fixes touching a file that could not be written failed
+	applied := result.Applied[:0]
+	for _, issue := range result.Applied {
+		var failed error
+		for _, edit := range issue.Fix.Edits {
+			if err, ok := failedFiles[edit.File]; ok {
+				failed = fmt.Errorf("failed to write %s: %w", edit.File, err)
+				break
+			}
+		}
+		if failed != nil {
+			result.Failed = append(result.Failed, FailedFix{Issue: issue, Err: failed})
+			continue
+		}
+		applied = append(applied, issue)
+	}
+	result.Applied = applied
 
-	return nil
+	for _, issue := range result.Applied {
+		fmt.Println(f.ui.Styles.Success.Render(
+			fmt.Sprintf("%s Fixed: %s", f.ui.Styles.IconSuccess, issue.Rule),
+		))
+		fmt.Printf("  %s\n", issue.Fix.Description)
+	}
+	f.printSkipped(result)
+
+	return result
 }
 
-func (f *Fixer) printDryRun(issue rules.Issue, fix *rules.Fix) {
-	fmt.Println(f.ui.Styles.Suggestion.Render(
-		fmt.Sprintf("Would fix: %s", issue.Rule),
-	))
-	fmt.Printf("  File: %s\n", issue.File)
-	fmt.Printf("  Line: %d\n", issue.Line)
-	fmt.Printf("  Fix: %s\n", fix.Description)
+// guard returns the check for which files fixes may write
+func (f *Fixer) guard() *pathGuard {
+	return newPathGuard(f.opts.RootPath, f.opts.AllowOutsideRoot)
+}
 
-	if len(fix.Edits) > 0 {
-		fmt.Println("  Changes:")
-		for _, edit := range fix.Edits {
-			fmt.Printf("    %s:%d-%d\n", edit.File, edit.StartLine, edit.EndLine)
-			if edit.NewContent != "" {
-				// Show a preview of the new content
-				preview := edit.NewContent
-				if len(preview) > 100 {
-					preview = preview[:100] + "..."
-				}
-				fmt.Println(f.ui.Styles.Success.Render(
-					"    + " + strings.ReplaceAll(preview, "\n", "\n    + "),
-				))
-			}
-		}
+// record adds a written file to the run's journal. Runs are only journaled
+// when the project root is known.
+func (f *Fixer) record(path string, original, fixed []byte) {
+	if f.opts.RootPath == "" {
+		return
 	}
-	fmt.Println()
+	if f.journal == nil {
+		f.journal = newJournal(f.opts.RootPath)
+	}
+	f.journal.record(f.opts.RootPath, path, original, fixed)
 }
 
-func (f *Fixer) applyEdit(edit rules.Edit) error {
-	// Read file
-	content, err := os.ReadFile(edit.File)
-	if err != nil {
-		return err
+// RunID returns the ID under which this run's changes were recorded, or ""
+// if nothing was written
+func (f *Fixer) RunID() string {
+	if f.journal == nil {
+		return ""
 	}
+	return f.journal.ID
+}
 
-	lines := strings.Split(string(content), "\n")
+// printSkipped reports fixes that were not applied
+func (f *Fixer) printSkipped(result *Result) {
+	for _, issue := range result.Conflicts {
+		fmt.Println(f.ui.Styles.Warning.Render(
+			fmt.Sprintf("%s Skipped: %s (overlaps another fix in %s:%d)", f.ui.Styles.IconWarning, issue.Rule, issue.File, issue.Line),
+		))
+	}
+	for _, failed := range result.Failed {
+		fmt.Println(f.ui.Styles.Error.Render(
+			fmt.Sprintf("%s Failed to fix %s: %v", f.ui.Styles.IconError, failed.Issue.Rule, failed.Err),
+		))
+	}
+}
 
-	// Validate line numbers
-	if edit.StartLine < 1 || edit.StartLine > len(lines) {
-		return fmt.Errorf("invalid start line: %d", edit.StartLine)
+// Patch writes the fixes for a batch of issues to w as a unified diff that
+// `git apply` accepts, without changing any files. git apply only patches
+// paths inside the project, so fixes to files outside the root are left out
+// and reported as failed even with AllowOutsideRoot.
+func (f *Fixer) Patch(issues []rules.Issue, w io.Writer) (*Result, error) {
+	var inside []rules.Issue
+	var outside []FailedFix
+	for _, issue := range issues {
+		if file, ok := f.outsideRoot(issue); ok {
+			outside = append(outside, FailedFix{Issue: issue, Err: fmt.Errorf("%s is outside the project root, so the patch cannot change it", file)})
+			continue
+		}
+		inside = append(inside, issue)
 	}
-	if edit.EndLine < edit.StartLine || edit.EndLine > len(lines) {
-		edit.EndLine = edit.StartLine
+
+	files, result := plan(inside, f.guard())
+	result.Failed = append(result.Failed, outside...)
+	for _, path := range changedPaths(files) {
+		diff := UnifiedDiff(f.displayPath(path), files[path].content, files[path].apply())
+		if _, err := io.WriteString(w, diff); err != nil {
+			return result, err
+		}
+		result.Files = append(result.Files, path)
 	}
+	return result, nil
+}
 
-	// Apply edit
-	newLines := make([]string, 0, len(lines))
-	newLines = append(newLines, lines[:edit.StartLine-1]...)
+// Diff returns the unified diff a single fix would make
+func (f *Fixer) Diff(issue rules.Issue) (string, error) {
+	files, result := plan([]rules.Issue{issue}, f.guard())
+	if len(result.Failed) > 0 {
+		return "", result.Failed[0].Err
+	}
+	if len(result.Conflicts) > 0 {
+		return "", fmt.Errorf("fix has overlapping edits")
+	}
 
-	if edit.NewContent != "" {
-		newLines = append(newLines, strings.Split(edit.NewContent, "\n")...)
+	var sb strings.Builder
+	for _, path := range changedPaths(files) {
+		sb.WriteString(UnifiedDiff(f.displayPath(path), files[path].content, files[path].apply()))
 	}
+	return sb.String(), nil
+}
 
-	newLines = append(newLines, lines[edit.EndLine:]...)
+func (f *Fixer) printDryRun(issue rules.Issue, fix *rules.Fix) {
+	fmt.Println(f.ui.Styles.Suggestion.Render(
+		fmt.Sprintf("Would fix: %s", issue.Rule),
+	))
+	fmt.Printf("  File: %s\n", issue.File)
+	fmt.Printf("  Line: %d\n", issue.Line)
+	fmt.Printf("  Fix: %s\n", fix.Description)
+	fmt.Println()
+}
 
-	// Write file
-	newContent := strings.Join(newLines, "\n")
-	return os.WriteFile(edit.File, []byte(newContent), 0644)
+// printDiffs prints the combined changes to each file as a unified diff
+func (f *Fixer) printDiffs(files map[string]*fileEdits) {
+	for _, path := range changedPaths(files) {
+		diff := UnifiedDiff(f.displayPath(path), files[path].content, files[path].apply())
+		for _, line := range strings.Split(strings.TrimSuffix(diff, "\n"), "\n") {
+			fmt.Println(f.ui.Styles.DiffLine(line).Render(line))
+		}
+		fmt.Println()
+	}
 }
 
-// AIFix generates an AI-assisted fix using Claude
-func (f *Fixer) AIFix(issue rules.Issue) (*rules.Fix, error) {
-	if !f.opts.AIAssisted {
-		return nil, fmt.Errorf("AI-assisted fixes not enabled")
+// outsideRoot returns the first file an issue's fix edits outside the
+// project root, if any
+func (f *Fixer) outsideRoot(issue rules.Issue) (string, bool) {
+	if f.opts.RootPath == "" || issue.Fix == nil {
+		return "", false
 	}
+	for _, edit := range issue.Fix.Edits {
+		if !within(f.opts.RootPath, filepath.Clean(edit.File)) {
+			return edit.File, true
+		}
+	}
+	return "", false
+}
 
-	// TODO: Implement AI-assisted fix generation using Claude
-	// This would:
-	// 1. Send the issue context to Claude
-	// 2. Ask for a suggested fix
-	// 3. Return the fix for user approval
+// displayPath returns a file's path relative to the project root, with
+// forward slashes as diffs expect
+func (f *Fixer) displayPath(path string) string {
+	if f.opts.RootPath != "" {
+		if rel, err := filepath.Rel(f.opts.RootPath, path); err == nil && !strings.HasPrefix(rel, "..") {
+			path = rel
+		}
+	}
+	return filepath.ToSlash(path)
+}
 
-	return nil, fmt.Errorf("AI-assisted fixes not yet implemented")
+// changedPaths returns the files with accepted edits, sorted
+func changedPaths(files map[string]*fileEdits) []string {
+	paths := make([]string, 0, len(files))
+	for path, fe := range files {
+		if len(fe.spans) > 0 {
+			paths = append(paths, path)
+		}
+	}
+	sort.Strings(paths)
+	return paths
 }
@@ -0,0 +1,94 @@
+package fixer
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/pthm/cclint/internal/rules"
+)
+
+func TestEditContent(t *testing.T) {
+	content := "# Title\nRead @docs/guid.md first\nlast line"
+
+	tests := []struct {
+		name    string
+		edit    rules.Edit
+		want    string
+		wantErr bool
+	}{
+		{
+			name: "replace whole line",
+			edit: rules.Edit{StartLine: 2, EndLine: 2, NewContent: "replaced"},
+			want: "# Title\nreplaced\nlast line",
+		},
+		{
+			name: "delete lines",
+			edit: rules.Edit{StartLine: 1, EndLine: 2},
+			want: "last line",
+		},
+		{
+			name: "replace token",
+			edit: rules.ReplaceText("", 2, 7, 19, "docs/guide.md"),
+			want: "# Title\nRead @docs/guide.md first\nlast line",
+		},
+		{
+			name: "insert at column",
+			edit: rules.ReplaceText("", 3, 1, 1, "the "),
+			want: "# Title\nRead @docs/guid.md first\nthe last line",
+		},
+		{
+			name: "append at end of line",
+			edit: rules.ReplaceText("", 1, 8, 8, "!"),
+			want: "# Title!\nRead @docs/guid.md first\nlast line",
+		},
+		{
+			name: "range across lines",
+			edit: rules.Edit{StartLine: 1, StartColumn: 3, EndLine: 2, EndColumn: 6, NewContent: ""},
+			want: "# @docs/guid.md first\nlast line",
+		},
+		{
+			name:    "column past end of line",
+			edit:    rules.ReplaceText("", 1, 3, 20, "x"),
+			wantErr: true,
+		},
+		{
+			name:    "end before start",
+			edit:    rules.ReplaceText("", 2, 5, 3, "x"),
+			wantErr: true,
+		},
+		{
+			name:    "line out of range",
+			edit:    rules.ReplaceText("", 9, 1, 1, "x"),
+			wantErr: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := editContent(content, tt.edit)
+			if tt.wantErr {
+				if err == nil {
+					t.Errorf("editContent() = %q, want error", got)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("editContent() error: %v", err)
+			}
+			if got != tt.want {
+				t.Errorf("editContent() = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
+
+// editContent returns content with a single edit applied
+func editContent(content string, edit rules.Edit) (string, error) {
+	fe := &fileEdits{content: content, lines: strings.Split(content, "\n")}
+	s, err := toSpan(fe, edit)
+	if err != nil {
+		return "", err
+	}
+	fe.spans = []span{s}
+	return fe.apply(), nil
+}
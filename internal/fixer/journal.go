@@ -0,0 +1,228 @@
+package fixer
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// StateDir is the directory under the project root where cclint keeps state
+const StateDir = ".cclint"
+
+// journalDir is where fix runs are recorded, relative to the project root
+var journalDir = filepath.Join(StateDir, "fixes")
+
+// runIDFormat is the time layout of fix run IDs
+const runIDFormat = "20060102-150405"
+
+// ErrNoFixRuns is returned when there is no recorded fix run to undo
+var ErrNoFixRuns = errors.New("no fix runs to undo")
+
+// Journal records the files changed by one fix run so it can be undone
+type Journal struct {
+	ID    string         `json:"id"`
+	Time  time.Time      `json:"time"`
+	Files []JournalEntry `json:"files"`
+}
+
+// JournalEntry is a file changed by a fix run
+type JournalEntry struct {
+	// Path is relative to the project root
+	Path string `json:"path"`
+
+	// OriginalHash and Original are the file before the run
+	OriginalHash string `json:"original_hash"`
+	Original     []byte `json:"original"`
+
+	// FixedHash is the file after the run; undo refuses if it no longer matches
+	FixedHash string `json:"fixed_hash"`
+}
+
+// hashContent returns the hex SHA-256 of content
+func hashContent(content []byte) string {
+	sum := sha256.Sum256(content)
+	return hex.EncodeToString(sum[:])
+}
+
+// record notes a file written by the run, keeping its content from before
+// the first write
+func (j *Journal) record(root, path string, original, fixed []byte) {
+	rel, err := filepath.Rel(root, path)
+	if err != nil {
+		rel = path
+	}
+	rel = filepath.ToSlash(rel)
+
+	for i := range j.Files {
+		if j.Files[i].Path == rel {
+			j.Files[i].FixedHash = hashContent(fixed)
+			return
+		}
+	}
+	j.Files = append(j.Files, JournalEntry{
+		Path:         rel,
+		OriginalHash: hashContent(original),
+		Original:     original,
+		FixedHash:    hashContent(fixed),
+	})
+}
+
+// save writes the journal under the project root
+func (j *Journal) save(root string) error {
+	dir := filepath.Join(root, journalDir)
+	if err := os.MkdirAll(dir, 0o755); err != nil {
+		return err
+	}
+
+	// Keep recorded file contents out of version control
+	ignore := filepath.Join(root, StateDir, ".gitignore")
+	if _, err := os.Stat(ignore); os.IsNotExist(err) {
+		if err := os.WriteFile(ignore, []byte("*\n"), 0o644); err != nil {
+			return err
+		}
+	}
+
+	data, err := json.MarshalIndent(j, "", "  ")
+	if err != nil {
+		return err
+	}
+	return os.WriteFile(filepath.Join(dir, j.ID+".json"), data, 0o644)
+}
+
+// newJournal starts a journal with a run ID based on the current time
+func newJournal(root string) *Journal {
+	now := time.Now()
+	id := now.Format(runIDFormat)
+	// Runs within the same second get a numeric suffix
+	for n := 2; ; n++ {
+		if _, err := os.Stat(filepath.Join(root, journalDir, id+".json")); os.IsNotExist(err) {
+			break
+		}
+		id = fmt.Sprintf("%s-%d", now.Format(runIDFormat), n)
+	}
+	return &Journal{ID: id, Time: now}
+}
+
+// FixRuns returns the IDs of recorded fix runs, oldest first
+func FixRuns(root string) ([]string, error) {
+	entries, err := os.ReadDir(filepath.Join(root, journalDir))
+	if os.IsNotExist(err) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	var ids []string
+	for _, entry := range entries {
+		if id, ok := strings.CutSuffix(entry.Name(), ".json"); ok && !entry.IsDir() {
+			ids = append(ids, id)
+		}
+	}
+	sort.Slice(ids, func(i, j int) bool {
+		iTime, iSeq := splitRunID(ids[i])
+		jTime, jSeq := splitRunID(ids[j])
+		if iTime != jTime {
+			return iTime < jTime
+		}
+		return iSeq < jSeq
+	})
+	return ids, nil
+}
+
+// IsRunID reports whether s has the form of a fix run ID, such as
+// 20261018-153045 or 20261018-153045-2
+func IsRunID(s string) bool {
+	stamp, _ := splitRunID(s)
+	_, err := time.Parse(runIDFormat, stamp)
+	return err == nil
+}
+
+// splitRunID splits a run ID into its timestamp and its position among the
+// runs started in the same second, so that run -10 sorts after run -9
+func splitRunID(id string) (string, int) {
+	n := len(runIDFormat)
+	if len(id) > n && id[n] == '-' {
+		if seq, err := strconv.Atoi(id[n+1:]); err == nil {
+			return id[:n], seq
+		}
+	}
+	return id, 1
+}
+
+// loadJournal reads a recorded fix run; an empty id loads the latest
+func loadJournal(root, id string) (*Journal, error) {
+	if id == "" {
+		ids, err := FixRuns(root)
+		if err != nil {
+			return nil, err
+		}
+		if len(ids) == 0 {
+			return nil, ErrNoFixRuns
+		}
+		id = ids[len(ids)-1]
+	}
+	if id != filepath.Base(id) {
+		return nil, fmt.Errorf("invalid fix run ID: %s", id)
+	}
+
+	data, err := os.ReadFile(filepath.Join(root, journalDir, id+".json"))
+	if os.IsNotExist(err) {
+		return nil, fmt.Errorf("no fix run %s", id)
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	var j Journal
+	if err := json.Unmarshal(data, &j); err != nil {
+		return nil, fmt.Errorf("invalid journal for fix run %s: %w", id, err)
+	}
+	return &j, nil
+}
+
+// Undo restores the files changed by a fix run and deletes its journal. An
+// empty id undoes the latest run. Nothing is restored if any file changed
+// after the run, since restoring would discard those changes.
+func Undo(root, id string) (*Journal, error) {
+	j, err := loadJournal(root, id)
+	if err != nil {
+		return nil, err
+	}
+
+	var changed []string
+	for _, entry := range j.Files {
+		current, err := os.ReadFile(filepath.Join(root, filepath.FromSlash(entry.Path)))
+		if err != nil || hashContent(current) != entry.FixedHash {
+			changed = append(changed, entry.Path)
+		}
+	}
+	if len(changed) > 0 {
+		return nil, fmt.Errorf("cannot undo fix run %s: files changed since the fix: %s", j.ID, strings.Join(changed, ", "))
+	}
+
+	for _, entry := range j.Files {
+		if hashContent(entry.Original) != entry.OriginalHash {
+			return nil, fmt.Errorf("journal for fix run %s is corrupt: %s does not match its hash", j.ID, entry.Path)
+		}
+	}
+
+	for _, entry := range j.Files {
+		if err := writeAtomic(filepath.Join(root, filepath.FromSlash(entry.Path)), entry.Original); err != nil {
+			return nil, fmt.Errorf("failed to restore %s: %w", entry.Path, err)
+		}
+	}
+
+	if err := os.Remove(filepath.Join(root, journalDir, j.ID+".json")); err != nil {
+		return j, fmt.Errorf("restored files but could not remove journal: %w", err)
+	}
+	return j, nil
+}
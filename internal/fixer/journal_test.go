@@ -0,0 +1,132 @@
+package fixer
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"slices"
+	"strings"
+	"testing"
+
+	"github.com/pthm/cclint/internal/rules"
+	"github.com/pthm/cclint/internal/ui"
+)
+
+func TestUndo(t *testing.T) {
+	dir := t.TempDir()
+	path := filepath.Join(dir, "CLAUDE.md")
+	original := "# Title\nRead @docs/guid.md\n"
+	if err := os.WriteFile(path, []byte(original), 0o644); err != nil {
+		t.Fatal(err)
+	}
+
+	if _, err := Undo(dir, ""); !errors.Is(err, ErrNoFixRuns) {
+		t.Fatalf("Undo with no runs = %v, want ErrNoFixRuns", err)
+	}
+
+	// Two passes of one run share a journal that keeps the first original
+	f := New(Options{RootPath: dir}, ui.New(io.Discard, io.Discard, "text"))
+	f.ApplyFixes([]rules.Issue{fixIssue("a", rules.ReplaceText(path, 2, 7, 19, "docs/guide.md"))})
+	f.ApplyFixes([]rules.Issue{fixIssue("b", rules.ReplaceText(path, 1, 3, 8, "Rules"))})
+	if f.RunID() == "" {
+		t.Fatal("expected the run to be recorded")
+	}
+	ids, err := FixRuns(dir)
+	if err != nil || len(ids) != 1 || ids[0] != f.RunID() {
+		t.Fatalf("FixRuns = %v, %v; want [%s]", ids, err, f.RunID())
+	}
+	if ignore, err := os.ReadFile(filepath.Join(dir, StateDir, ".gitignore")); err != nil || string(ignore) != "*\n" {
+		t.Errorf(".gitignore = %q, %v", ignore, err)
+	}
+
+	// Refuses while the file differs from what the run wrote
+	fixed, _ := os.ReadFile(path)
+	if err := os.WriteFile(path, append(fixed, "edited\n"...), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := Undo(dir, f.RunID()); err == nil || !strings.Contains(err.Error(), "CLAUDE.md") {
+		t.Fatalf("Undo after a change = %v, want a refusal naming CLAUDE.md", err)
+	}
+	if got, _ := os.ReadFile(path); !strings.HasSuffix(string(got), "edited\n") {
+		t.Error("refused undo changed the file")
+	}
+
+	if err := os.WriteFile(path, fixed, 0o644); err != nil {
+		t.Fatal(err)
+	}
+	journal, err := Undo(dir, "")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(journal.Files) != 1 || journal.Files[0].Path != "CLAUDE.md" {
+		t.Errorf("journal files = %+v", journal.Files)
+	}
+	if got, _ := os.ReadFile(path); string(got) != original {
+		t.Errorf("restored content = %q, want %q", got, original)
+	}
+
+	// The undone run is forgotten
+	if _, err := Undo(dir, ""); !errors.Is(err, ErrNoFixRuns) {
+		t.Errorf("second Undo = %v, want ErrNoFixRuns", err)
+	}
+	if _, err := Undo(dir, "../CLAUDE"); err == nil {
+		t.Error("expected an error for an invalid run ID")
+	}
+}
+
+func TestDryRunNotJournaled(t *testing.T) {
+	dir := t.TempDir()
+	path := filepath.Join(dir, "CLAUDE.md")
+	if err := os.WriteFile(path, []byte("# Title\n"), 0o644); err != nil {
+		t.Fatal(err)
+	}
+
+	f := New(Options{DryRun: true, RootPath: dir}, ui.New(io.Discard, io.Discard, "text"))
+	f.ApplyFixes([]rules.Issue{fixIssue("a", rules.ReplaceText(path, 1, 3, 8, "Rules"))})
+	if f.RunID() != "" {
+		t.Error("dry run was journaled")
+	}
+	if _, err := os.Stat(filepath.Join(dir, StateDir)); !os.IsNotExist(err) {
+		t.Error("dry run created the state directory")
+	}
+}
+
+func TestFixRunsOrder(t *testing.T) {
+	dir := t.TempDir()
+	journals := filepath.Join(dir, journalDir)
+	if err := os.MkdirAll(journals, 0o755); err != nil {
+		t.Fatal(err)
+	}
+
+	// Runs within one second are numbered from -2; -10 is newer than -9
+	want := []string{"20261018-153044", "20261018-153045"}
+	for n := 2; n <= 10; n++ {
+		want = append(want, fmt.Sprintf("20261018-153045-%d", n))
+	}
+	want = append(want, "20261018-153046")
+	for _, id := range want {
+		if err := os.WriteFile(filepath.Join(journals, id+".json"), []byte("{}"), 0o644); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	ids, err := FixRuns(dir)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !slices.Equal(ids, want) {
+		t.Errorf("FixRuns = %v, want %v", ids, want)
+	}
+	for _, id := range want {
+		if !IsRunID(id) {
+			t.Errorf("IsRunID(%q) = false", id)
+		}
+	}
+	for _, s := range []string{".", "./project", "20261018", "20261018-153045-x", "20261318-153045"} {
+		if IsRunID(s) {
+			t.Errorf("IsRunID(%q) = true", s)
+		}
+	}
+}
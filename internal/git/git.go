@@ -0,0 +1,116 @@
+// Package git queries the git repository a project lives in. Every function
+// degrades gracefully when git is not installed or the project is not a
+// repository, since linting must work on plain directories too.
+package git
+
+import (
+	"bytes"
+	"errors"
+	"os/exec"
+	"path/filepath"
+	"strings"
+)
+
+// ErrNotRepository is returned when git is unavailable or dir is not inside
+// a git work tree
+var ErrNotRepository = errors.New("not a git repository")
+
+// run executes git in dir and returns its stdout
+func run(dir string, stdin []byte, args ...string) ([]byte, error) {
+	cmd := exec.Command("git", append([]string{"-C", dir}, args...)...)
+	if stdin != nil {
+		cmd.Stdin = bytes.NewReader(stdin)
+	}
+	var stdout bytes.Buffer
+	cmd.Stdout = &stdout
+	err := cmd.Run()
+	return stdout.Bytes(), err
+}
+
+// IsRepository reports whether dir is inside a git work tree
+func IsRepository(dir string) bool {
+	out, err := run(dir, nil, "rev-parse", "--is-inside-work-tree")
+	return err == nil && strings.TrimSpace(string(out)) == "true"
+}
+
+// Ignored returns the subset of paths that git ignores. Tracked files are
+// never reported, even if they match an ignore pattern, because they still
+// exist in every clone.
+func Ignored(dir string, paths []string) (map[string]bool, error) {
+	if !IsRepository(dir) {
+		return nil, ErrNotRepository
+	}
+
+	ignored := make(map[string]bool)
+	if len(paths) == 0 {
+		return ignored, nil
+	}
+
+	var stdin bytes.Buffer
+	for _, p := range paths {
+		stdin.WriteString(p)
+		stdin.WriteByte(0)
+	}
+
+	out, err := run(dir, stdin.Bytes(), "check-ignore", "--stdin", "-z")
+	if err != nil {
+		// Exit status 1 means no path is ignored
+		var exitErr *exec.ExitError
+		if errors.As(err, &exitErr) && exitErr.ExitCode() == 1 {
+			return ignored, nil
+		}
+		return nil, err
+	}
+
+	for _, p := range strings.Split(string(out), "\x00") {
+		if p == "" {
+			continue
+		}
+		if !filepath.IsAbs(p) {
+			p = filepath.Join(dir, p)
+		}
+		ignored[filepath.Clean(p)] = true
+	}
+	return ignored, nil
+}
+
+// TopLevel returns the absolute path of the repository containing dir
+func TopLevel(dir string) (string, error) {
+	out, err := run(dir, nil, "rev-parse", "--show-toplevel")
+	if err != nil {
+		return "", ErrNotRepository
+	}
+	return filepath.Clean(strings.TrimSpace(string(out))), nil
+}
+
+// Renames maps each path that was renamed in the repository's history to
+// the path it was most recently renamed to. Paths are absolute. Chains of
+// renames are not collapsed; callers follow them as needed.
+func Renames(dir string) (map[string]string, error) {
+	top, err := TopLevel(dir)
+	if err != nil {
+		return nil, err
+	}
+
+	out, err := run(top, nil, "log", "-M", "--diff-filter=R", "--name-status", "--format=", "-z")
+	if err != nil {
+		return nil, err
+	}
+
+	// Records are "R<score>\0<old>\0<new>\0", newest commit first
+	renames := make(map[string]string)
+	fields := strings.Split(string(out), "\x00")
+	for i := 0; i+2 < len(fields); {
+		status := strings.TrimSpace(fields[i])
+		if !strings.HasPrefix(status, "R") {
+			i++
+			continue
+		}
+		oldPath := filepath.Join(top, fields[i+1])
+		if _, seen := renames[oldPath]; !seen {
+			renames[oldPath] = filepath.Join(top, fields[i+2])
+		}
+		i += 3
+	}
+	return renames, nil
+}
@@ -0,0 +1,63 @@
+package git
+
+import (
+	"os"
+	"os/exec"
+	"path/filepath"
+	"testing"
+)
+
+func TestIgnored(t *testing.T) {
+	if _, err := exec.LookPath("git"); err != nil {
+		t.Skip("git not installed")
+	}
+
+	dir := t.TempDir()
+	if _, err := Ignored(dir, nil); err != ErrNotRepository {
+		t.Errorf("Ignored() outside a repository: err = %v, want ErrNotRepository", err)
+	}
+
+	if out, err := exec.Command("git", "-C", dir, "init", "-q").CombinedOutput(); err != nil {
+		t.Fatalf("git init failed: %v: %s", err, out)
+	}
+	for name, content := range map[string]string{
+		".gitignore":     "*.local.md\nbuild/\n",
+		"notes.local.md": "ignored",
+		"build/out.md":   "ignored",
+		"CLAUDE.md":      "tracked",
+	} {
+		path := filepath.Join(dir, name)
+		if err := os.MkdirAll(filepath.Dir(path), 0o755); err != nil {
+			t.Fatal(err)
+		}
+		if err := os.WriteFile(path, []byte(content), 0o644); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	if !IsRepository(dir) {
+		t.Fatal("IsRepository() = false after git init")
+	}
+
+	paths := []string{
+		filepath.Join(dir, "notes.local.md"),
+		filepath.Join(dir, "build/out.md"),
+		filepath.Join(dir, "CLAUDE.md"),
+	}
+	ignored, err := Ignored(dir, paths)
+	if err != nil {
+		t.Fatalf("Ignored() error: %v", err)
+	}
+	if !ignored[paths[0]] || !ignored[paths[1]] {
+		t.Errorf("Expected ignored files to be reported, got %v", ignored)
+	}
+	if ignored[paths[2]] {
+		t.Errorf("CLAUDE.md should not be ignored")
+	}
+
+	// No ignored paths is not an error
+	ignored, err = Ignored(dir, paths[2:])
+	if err != nil || len(ignored) != 0 {
+		t.Errorf("Ignored(CLAUDE.md) = %v, %v; want empty, nil", ignored, err)
+	}
+}
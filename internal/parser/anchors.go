@@ -0,0 +1,55 @@
+package parser
+
+import (
+	"fmt"
+	"regexp"
+	"strings"
+	"unicode"
+)
+
+// htmlAnchorPattern matches explicit HTML anchors (<a name="x">, id="x")
+var htmlAnchorPattern = regexp.MustCompile(`(?i)<[a-z][^>]*\s(?:name|id)\s*=\s*["']([^"']+)["']`)
+
+// HeadingSlug returns the GitHub-style anchor for a heading: lowercased,
+// punctuation removed and spaces replaced with hyphens
+func HeadingSlug(heading string) string {
+	var sb strings.Builder
+	for _, r := range strings.ToLower(strings.TrimSpace(heading)) {
+		switch {
+		case unicode.IsLetter(r), unicode.IsNumber(r), r == '-', r == '_':
+			sb.WriteRune(r)
+		case r == ' ':
+			sb.WriteRune('-')
+		}
+	}
+	return sb.String()
+}
+
+// Anchors returns the link fragments a markdown file defines: heading slugs
+// (with -1, -2 suffixes for repeated headings, as GitHub does) and explicit
+// HTML name/id anchors
+func (f *ParsedFile) Anchors() []string {
+	var anchors []string
+	counts := make(map[string]int)
+
+	var walk func(sections []Section)
+	walk = func(sections []Section) {
+		for _, section := range sections {
+			slug := HeadingSlug(section.Title)
+			if n := counts[slug]; n > 0 {
+				anchors = append(anchors, fmt.Sprintf("%s-%d", slug, n))
+			} else {
+				anchors = append(anchors, slug)
+			}
+			counts[slug]++
+			walk(section.Subsections)
+		}
+	}
+	walk(f.Sections)
+
+	for _, match := range htmlAnchorPattern.FindAllStringSubmatch(string(f.Content), -1) {
+		anchors = append(anchors, match[1])
+	}
+
+	return anchors
+}
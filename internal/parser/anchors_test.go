@@ -0,0 +1,46 @@
+package parser
+
+import (
+	"testing"
+)
+
+func TestHeadingSlug(t *testing.T) {
+	tests := []struct {
+		heading string
+		want    string
+	}{
+		{"Setup", "setup"},
+		{"Getting Started", "getting-started"},
+		{"What's new?", "whats-new"},
+		{"API & CLI (v2.0)", "api--cli-v20"},
+		{"snake_case and kebab-case", "snake_case-and-kebab-case"},
+		{"Überblick", "überblick"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.heading, func(t *testing.T) {
+			if got := HeadingSlug(tt.heading); got != tt.want {
+				t.Errorf("HeadingSlug(%q) = %q, want %q", tt.heading, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestParsedFileAnchors(t *testing.T) {
+	content := "# Guide\n\n## Setup\n\n### Setup\n\n<a name=\"custom-anchor\"></a>\n"
+	parsed, err := (&MarkdownParser{}).Parse("guide.md", []byte(content))
+	if err != nil {
+		t.Fatalf("Parse() error: %v", err)
+	}
+
+	want := []string{"guide", "setup", "setup-1", "custom-anchor"}
+	got := parsed.Anchors()
+	if len(got) != len(want) {
+		t.Fatalf("Anchors() = %v, want %v", got, want)
+	}
+	for i := range want {
+		if got[i] != want[i] {
+			t.Errorf("Anchors() = %v, want %v", got, want)
+		}
+	}
+}
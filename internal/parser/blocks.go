@@ -0,0 +1,377 @@
+package parser
+
+import (
+	"bytes"
+	"strings"
+
+	"github.com/yuin/goldmark/ast"
+	extast "github.com/yuin/goldmark/extension/ast"
+)
+
+// BlockKind identifies the type of a markdown block
+type BlockKind int
+
+const (
+	// BlockParagraph is a paragraph of prose
+	BlockParagraph BlockKind = iota
+	// BlockHeading is an ATX or setext heading
+	BlockHeading
+	// BlockListItem is the text of a list item (nested blocks in the item are separate)
+	BlockListItem
+	// BlockQuote is a paragraph inside a blockquote
+	BlockQuote
+	// BlockCode is a fenced or indented code block
+	BlockCode
+	// BlockTable is a GFM table
+	BlockTable
+	// BlockHTML is a raw HTML block
+	BlockHTML
+	// BlockHTMLComment is an HTML comment block (<!-- ... -->)
+	BlockHTMLComment
+)
+
+func (k BlockKind) String() string {
+	switch k {
+	case BlockParagraph:
+		return "paragraph"
+	case BlockHeading:
+		return "heading"
+	case BlockListItem:
+		return "list-item"
+	case BlockQuote:
+		return "blockquote"
+	case BlockCode:
+		return "code"
+	case BlockTable:
+		return "table"
+	case BlockHTML:
+		return "html"
+	case BlockHTMLComment:
+		return "html-comment"
+	default:
+		return "unknown"
+	}
+}
+
+// IsProse reports whether the block holds prose rather than code, markup or tabular data
+func (k BlockKind) IsProse() bool {
+	return k == BlockParagraph || k == BlockHeading || k == BlockListItem || k == BlockQuote
+}
+
+// Block is a markdown block with its source position. Line i of Text is on
+// file line StartLine+i; list markers, blockquote prefixes and code fences
+// are not part of Text.
+type Block struct {
+	Kind BlockKind
+
+	// Text is the block content
+	Text string
+
+	// StartLine and EndLine are 1-based file lines (frontmatter included)
+	StartLine int
+	EndLine   int
+
+	// Level is the heading level for headings
+	Level int
+
+	// ListDepth is the list nesting depth (1 for a top-level list, 0 outside lists)
+	ListDepth int
+
+	// InQuote is true for blocks inside a blockquote
+	InQuote bool
+
+	// Language is the info string language of fenced code blocks
+	Language string
+}
+
+// Lines returns the block's text split into lines
+func (b Block) Lines() []string {
+	return strings.Split(b.Text, "\n")
+}
+
+// Link is an inline link, image or autolink in a markdown file
+type Link struct {
+	// Text is the link text (or alt text for images)
+	Text string
+
+	// Destination is the link target as written
+	Destination string
+
+	// Image is true for ![alt](src) images
+	Image bool
+
+	// AutoLink is true for <https://...> style links
+	AutoLink bool
+
+	// Line and Column are the 1-based file position of the link
+	Line   int
+	Column int
+}
+
+// blockWalker collects blocks and links from a goldmark document
+type blockWalker struct {
+	source     []byte
+	lines      lineIndex
+	lineOffset int
+	blocks     []Block
+	links      []Link
+
+	// linkFrom is where the search for the next link's opening marker starts
+	linkFrom int
+}
+
+// extractBlocks returns the blocks and links of a markdown body. lineOffset
+// is the number of file lines before the body (i.e. the frontmatter).
+func extractBlocks(doc ast.Node, source []byte, lineOffset int) ([]Block, []Link) {
+	w := &blockWalker{
+		source:     source,
+		lines:      newLineIndex(source),
+		lineOffset: lineOffset,
+	}
+	w.walkChildren(doc, 0, false)
+	return w.blocks, w.links
+}
+
+func (w *blockWalker) walkChildren(n ast.Node, listDepth int, inQuote bool) {
+	for child := n.FirstChild(); child != nil; child = child.NextSibling() {
+		w.walk(child, listDepth, inQuote)
+	}
+}
+
+func (w *blockWalker) walk(n ast.Node, listDepth int, inQuote bool) {
+	switch node := n.(type) {
+	case *ast.Heading:
+		block := w.leafBlock(node, BlockHeading, listDepth, inQuote)
+		block.Level = node.Level
+		w.add(block)
+		w.collectLinks(node)
+
+	case *ast.Paragraph, *ast.TextBlock:
+		kind := BlockParagraph
+		if inQuote {
+			kind = BlockQuote
+		}
+		w.add(w.leafBlock(node, kind, listDepth, inQuote))
+		w.collectLinks(node)
+
+	case *ast.List:
+		w.walkChildren(node, listDepth+1, inQuote)
+
+	case *ast.ListItem:
+		for child := node.FirstChild(); child != nil; child = child.NextSibling() {
+			// The item's first text block is the item itself
+			if child == node.FirstChild() && (child.Kind() == ast.KindParagraph || child.Kind() == ast.KindTextBlock) {
+				w.add(w.leafBlock(child, BlockListItem, listDepth, inQuote))
+				w.collectLinks(child)
+				continue
+			}
+			w.walk(child, listDepth, inQuote)
+		}
+
+	case *ast.Blockquote:
+		w.walkChildren(node, listDepth, true)
+
+	case *ast.FencedCodeBlock:
+		block := w.leafBlock(node, BlockCode, listDepth, inQuote)
+		block.Language = string(node.Language(w.source))
+		if node.Lines().Len() == 0 && node.Info != nil {
+			// Empty code block: place it on the opening fence
+			block.StartLine = w.line(node.Info.Segment.Start)
+			block.EndLine = block.StartLine
+		}
+		w.add(block)
+
+	case *ast.CodeBlock:
+		w.add(w.leafBlock(node, BlockCode, listDepth, inQuote))
+
+	case *ast.HTMLBlock:
+		kind := BlockHTML
+		if node.HTMLBlockType == ast.HTMLBlockType2 {
+			kind = BlockHTMLComment
+		}
+		block := w.leafBlock(node, kind, listDepth, inQuote)
+		if node.HasClosure() {
+			closure := strings.TrimRight(string(node.ClosureLine.Value(w.source)), "\r\n")
+			if block.Text == "" {
+				block.Text = closure
+				block.StartLine = w.line(node.ClosureLine.Start)
+			} else {
+				block.Text += "\n" + closure
+			}
+			block.EndLine = w.line(node.ClosureLine.Start)
+		}
+		w.add(block)
+
+	case *extast.Table:
+		start, stop, ok := w.span(node)
+		if !ok {
+			return
+		}
+		startLine, endLine := w.line(start), w.line(stop-1)
+		w.add(Block{
+			Kind:      BlockTable,
+			Text:      w.sourceLines(startLine, endLine),
+			StartLine: startLine,
+			EndLine:   endLine,
+			ListDepth: listDepth,
+			InQuote:   inQuote,
+		})
+		w.collectLinks(node)
+
+	default:
+		w.walkChildren(node, listDepth, inQuote)
+	}
+}
+
+// leafBlock builds a block from a node's line segments
+func (w *blockWalker) leafBlock(n ast.Node, kind BlockKind, listDepth int, inQuote bool) Block {
+	block := Block{Kind: kind, ListDepth: listDepth, InQuote: inQuote}
+
+	segments := n.Lines()
+	if segments.Len() == 0 {
+		return block
+	}
+
+	lines := make([]string, segments.Len())
+	for i := 0; i < segments.Len(); i++ {
+		seg := segments.At(i)
+		lines[i] = strings.TrimRight(string(seg.Value(w.source)), "\r\n")
+	}
+	block.Text = strings.Join(lines, "\n")
+	block.StartLine = w.line(segments.At(0).Start)
+	block.EndLine = w.line(segments.At(segments.Len() - 1).Start)
+	return block
+}
+
+// add appends a block that has a position
+func (w *blockWalker) add(block Block) {
+	if block.StartLine == 0 {
+		return
+	}
+	w.blocks = append(w.blocks, block)
+}
+
+// collectLinks records the links inside an inline container
+func (w *blockWalker) collectLinks(n ast.Node) {
+	w.linkFrom = 0
+	if start, _, ok := w.span(n); ok {
+		w.linkFrom = start
+	}
+	_ = ast.Walk(n, func(child ast.Node, entering bool) (ast.WalkStatus, error) {
+		if !entering {
+			return ast.WalkContinue, nil
+		}
+
+		switch link := child.(type) {
+		case *ast.Link:
+			w.addLink(link, string(link.Destination), false, false, "[")
+		case *ast.Image:
+			w.addLink(link, string(link.Destination), true, false, "![")
+		case *ast.AutoLink:
+			w.addLink(link, string(link.URL(w.source)), false, true, "<"+string(link.URL(w.source)))
+		}
+		return ast.WalkContinue, nil
+	})
+}
+
+// addLink records a link at its opening marker ("[", "![" or "<url").
+// Links need not have text to locate, as in [](file.md) or ![](image.png),
+// so the marker is searched for after the previous link and the previous
+// sibling; links are visited in source order.
+func (w *blockWalker) addLink(n ast.Node, destination string, image, autoLink bool, marker string) {
+	link := Link{
+		Text:        inlineText(n, w.source),
+		Destination: destination,
+		Image:       image,
+		AutoLink:    autoLink,
+	}
+
+	from := w.linkFrom
+	if prev := n.PreviousSibling(); prev != nil {
+		if _, stop, ok := w.span(prev); ok && stop > from {
+			from = stop
+		}
+	}
+	idx := bytes.Index(w.source[from:], []byte(marker))
+	if idx < 0 {
+		return
+	}
+	offset := from + idx
+	w.linkFrom = offset + len(marker)
+
+	line, col := w.lines.lineCol(offset)
+	link.Line, link.Column = line+w.lineOffset, col
+	w.links = append(w.links, link)
+}
+
+// span returns the source range covered by a node's line segments and
+// descendant text
+func (w *blockWalker) span(n ast.Node) (int, int, bool) {
+	start, stop, found := 0, 0, false
+	extend := func(s, e int) {
+		if !found || s < start {
+			start = s
+		}
+		if !found || e > stop {
+			stop = e
+		}
+		found = true
+	}
+
+	_ = ast.Walk(n, func(child ast.Node, entering bool) (ast.WalkStatus, error) {
+		if !entering {
+			return ast.WalkContinue, nil
+		}
+		if text, ok := child.(*ast.Text); ok {
+			extend(text.Segment.Start, text.Segment.Stop)
+		}
+		if child.Type() == ast.TypeBlock {
+			lines := child.Lines()
+			for i := 0; i < lines.Len(); i++ {
+				extend(lines.At(i).Start, lines.At(i).Stop)
+			}
+		}
+		return ast.WalkContinue, nil
+	})
+
+	return start, stop, found
+}
+
+// line converts a body byte offset to a 1-based file line
+func (w *blockWalker) line(offset int) int {
+	line, _ := w.lines.lineCol(offset)
+	return line + w.lineOffset
+}
+
+// sourceLines returns the raw body text between two 1-based file lines
+func (w *blockWalker) sourceLines(startLine, endLine int) string {
+	all := strings.Split(string(w.source), "\n")
+	start, end := startLine-w.lineOffset-1, endLine-w.lineOffset
+	if start < 0 || end > len(all) || start >= end {
+		return ""
+	}
+	return strings.Join(all[start:end], "\n")
+}
+
+// inlineText concatenates the text inside an inline node
+func inlineText(n ast.Node, source []byte) string {
+	var sb strings.Builder
+	_ = ast.Walk(n, func(child ast.Node, entering bool) (ast.WalkStatus, error) {
+		if !entering {
+			return ast.WalkContinue, nil
+		}
+		switch t := child.(type) {
+		case *ast.Text:
+			sb.Write(t.Segment.Value(source))
+			if t.SoftLineBreak() {
+				sb.WriteString(" ")
+			}
+		case *ast.String:
+			sb.Write(t.Value)
+		case *ast.AutoLink:
+			sb.Write(t.Label(source))
+		}
+		return ast.WalkContinue, nil
+	})
+	return sb.String()
+}
@@ -0,0 +1,131 @@
+package parser
+
+import (
+	"testing"
+)
+
+func TestMarkdownBlocks(t *testing.T) {
+	content := "---\n" +
+		"description: Example\n" +
+		"---\n" +
+		"# Rules\n" +
+		"\n" +
+		"Read [the guide](docs/guide.md) first.\n" +
+		"\n" +
+		"- Never:\n" +
+		"  - force push\n" +
+		"- Run <https://example.com/ci>\n" +
+		"\n" +
+		"```bash\n" +
+		"git push --force\n" +
+		"```\n" +
+		"\n" +
+		"| Tool | Use |\n" +
+		"| ---- | --- |\n" +
+		"| Bash | ![logo](img/logo.png) |\n" +
+		"\n" +
+		"> Quoted advice\n" +
+		"\n" +
+		"<!--\n" +
+		"hidden note\n" +
+		"-->\n"
+
+	parsed, err := (&MarkdownParser{}).Parse("CLAUDE.md", []byte(content))
+	if err != nil {
+		t.Fatalf("Parse() error: %v", err)
+	}
+
+	want := []struct {
+		kind      BlockKind
+		text      string
+		startLine int
+		endLine   int
+		listDepth int
+	}{
+		{BlockHeading, "Rules", 4, 4, 0},
+		{BlockParagraph, "Read [the guide](docs/guide.md) first.", 6, 6, 0},
+		{BlockListItem, "Never:", 8, 8, 1},
+		{BlockListItem, "force push", 9, 9, 2},
+		{BlockListItem, "Run <https://example.com/ci>", 10, 10, 1},
+		{BlockCode, "git push --force", 13, 13, 0},
+		{BlockTable, "| Tool | Use |\n| ---- | --- |\n| Bash | ![logo](img/logo.png) |", 16, 18, 0},
+		{BlockQuote, "Quoted advice", 20, 20, 0},
+		{BlockHTMLComment, "<!--\nhidden note\n-->", 22, 24, 0},
+	}
+
+	if len(parsed.Blocks) != len(want) {
+		for _, b := range parsed.Blocks {
+			t.Logf("%s %d-%d %q", b.Kind, b.StartLine, b.EndLine, b.Text)
+		}
+		t.Fatalf("got %d blocks, want %d", len(parsed.Blocks), len(want))
+	}
+
+	for i, w := range want {
+		got := parsed.Blocks[i]
+		if got.Kind != w.kind || got.Text != w.text || got.StartLine != w.startLine || got.EndLine != w.endLine || got.ListDepth != w.listDepth {
+			t.Errorf("block %d = %s %d-%d depth %d %q, want %s %d-%d depth %d %q",
+				i, got.Kind, got.StartLine, got.EndLine, got.ListDepth, got.Text,
+				w.kind, w.startLine, w.endLine, w.listDepth, w.text)
+		}
+	}
+
+	if lang := parsed.Blocks[5].Language; lang != "bash" {
+		t.Errorf("code block Language = %q, want bash", lang)
+	}
+
+	wantLinks := []Link{
+		{Text: "the guide", Destination: "docs/guide.md", Line: 6, Column: 6},
+		{Text: "https://example.com/ci", Destination: "https://example.com/ci", AutoLink: true, Line: 10, Column: 7},
+		{Text: "logo", Destination: "img/logo.png", Image: true, Line: 18, Column: 10},
+	}
+	if len(parsed.Links) != len(wantLinks) {
+		t.Fatalf("got links %+v, want %+v", parsed.Links, wantLinks)
+	}
+	for i, w := range wantLinks {
+		if parsed.Links[i] != w {
+			t.Errorf("link %d = %+v, want %+v", i, parsed.Links[i], w)
+		}
+	}
+
+	if len(parsed.Sections) != 1 || parsed.Sections[0].StartLine != 4 {
+		t.Errorf("Sections = %+v, want Rules at line 4", parsed.Sections)
+	}
+}
+
+func TestMarkdownLinkPositions(t *testing.T) {
+	content := "[](missing.md) and [x](gone.md) then ![](nope.png)\n" +
+		"\n" +
+		"See [*guide*](a.md), [`cfg`](b.md) and [![img](c.png)](d.md).\n" +
+		"Literal [note] before [real](e.md).\n"
+
+	parsed, err := (&MarkdownParser{}).Parse("CLAUDE.md", []byte(content))
+	if err != nil {
+		t.Fatalf("Parse() error: %v", err)
+	}
+
+	// Links without text are kept, and columns point at the opening [ or ![
+	// rather than the first text inside the link
+	want := []struct {
+		destination string
+		line        int
+		column      int
+	}{
+		{"missing.md", 1, 1},
+		{"gone.md", 1, 20},
+		{"nope.png", 1, 38},
+		{"a.md", 3, 5},
+		{"b.md", 3, 22},
+		{"d.md", 3, 40},
+		{"c.png", 3, 41},
+		{"e.md", 4, 23},
+	}
+	if len(parsed.Links) != len(want) {
+		t.Fatalf("got links %+v, want %d", parsed.Links, len(want))
+	}
+	for i, w := range want {
+		got := parsed.Links[i]
+		if got.Destination != w.destination || got.Line != w.line || got.Column != w.column {
+			t.Errorf("link %d = %s at %d:%d, want %s at %d:%d", i, got.Destination, got.Line, got.Column, w.destination, w.line, w.column)
+		}
+	}
+}
@@ -0,0 +1,141 @@
+package parser
+
+import (
+	"fmt"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+
+	"gopkg.in/yaml.v3"
+)
+
+// Frontmatter is the YAML frontmatter block of a markdown file, with the
+// source positions of its keys
+type Frontmatter struct {
+	// Fields are the decoded frontmatter values (nil if the YAML is invalid)
+	Fields map[string]interface{}
+
+	// Body is the content after the closing --- delimiter
+	Body []byte
+
+	// StartLine and EndLine are the 1-based lines of the opening and closing ---
+	StartLine int
+	EndLine   int
+
+	// Err is set when the frontmatter is not a valid YAML mapping
+	Err error
+
+	// ErrLine is the 1-based file line Err refers to
+	ErrLine int
+
+	// keyLines maps each top-level key to its 1-based file line
+	keyLines map[string]int
+}
+
+// yamlErrLinePattern extracts the line number from yaml.v3 error messages
+var yamlErrLinePattern = regexp.MustCompile(`line (\d+)`)
+
+// ParseFrontmatterBlock extracts the frontmatter block from markdown content.
+// Returns nil if the content has no frontmatter delimiters. Invalid YAML is
+// reported through Err rather than discarded.
+func ParseFrontmatterBlock(content []byte) *Frontmatter {
+	s := string(content)
+
+	// Must start with ---
+	if !strings.HasPrefix(s, "---") {
+		return nil
+	}
+
+	// Find the closing ---
+	rest := s[3:]
+	endIdx := strings.Index(rest, "\n---")
+	if endIdx == -1 {
+		return nil
+	}
+
+	// Remaining content after frontmatter
+	remaining := rest[endIdx+4:] // +4 for "\n---"
+	if strings.HasPrefix(remaining, "\n") {
+		remaining = remaining[1:]
+	}
+
+	fm := &Frontmatter{
+		Body:      []byte(remaining),
+		StartLine: 1,
+		EndLine:   strings.Count(rest[:endIdx], "\n") + 2,
+		keyLines:  make(map[string]int),
+	}
+
+	// The YAML starts on the opening delimiter's line, so YAML line numbers
+	// are file line numbers
+	source := rest[:endIdx]
+	if strings.TrimSpace(source) == "" {
+		fm.Fields = map[string]interface{}{}
+		return fm
+	}
+
+	var doc yaml.Node
+	if err := yaml.Unmarshal([]byte(source), &doc); err != nil {
+		fm.setErr(err)
+		return fm
+	}
+
+	if len(doc.Content) == 0 || doc.Content[0].Kind != yaml.MappingNode {
+		fm.Err = fmt.Errorf("frontmatter must be a mapping of keys to values")
+		fm.ErrLine = 2
+		if len(doc.Content) > 0 {
+			fm.ErrLine = doc.Content[0].Line
+		}
+		return fm
+	}
+
+	var fields map[string]interface{}
+	if err := doc.Decode(&fields); err != nil {
+		fm.setErr(err)
+		return fm
+	}
+	fm.Fields = fields
+
+	mapping := doc.Content[0]
+	for i := 0; i+1 < len(mapping.Content); i += 2 {
+		key := mapping.Content[i]
+		if _, seen := fm.keyLines[key.Value]; !seen {
+			fm.keyLines[key.Value] = key.Line
+		}
+	}
+
+	return fm
+}
+
+// setErr records a YAML error and the line it refers to
+func (f *Frontmatter) setErr(err error) {
+	f.Err = err
+	f.ErrLine = f.StartLine
+	if match := yamlErrLinePattern.FindStringSubmatch(err.Error()); match != nil {
+		if line, convErr := strconv.Atoi(match[1]); convErr == nil {
+			f.ErrLine = line
+		}
+	}
+}
+
+// KeyLine returns the 1-based file line of a top-level key, or the opening
+// delimiter's line if the key is not present
+func (f *Frontmatter) KeyLine(key string) int {
+	if line, ok := f.keyLines[key]; ok {
+		return line
+	}
+	return f.StartLine
+}
+
+// Keys returns the top-level keys in source order
+func (f *Frontmatter) Keys() []string {
+	keys := make([]string, 0, len(f.keyLines))
+	for key := range f.keyLines {
+		keys = append(keys, key)
+	}
+	sort.Slice(keys, func(i, j int) bool {
+		return f.keyLines[keys[i]] < f.keyLines[keys[j]]
+	})
+	return keys
+}
@@ -0,0 +1,76 @@
+package parser
+
+import (
+	"testing"
+)
+
+func TestParseFrontmatterBlock(t *testing.T) {
+	tests := []struct {
+		name     string
+		content  string
+		wantNil  bool
+		wantErr  bool
+		errLine  int
+		keyLines map[string]int
+		endLine  int
+	}{
+		{
+			name:    "no frontmatter",
+			content: "# Title\n",
+			wantNil: true,
+		},
+		{
+			name:     "valid frontmatter",
+			content:  "---\nname: reviewer\ndescription: Reviews code\n---\n\nBody\n",
+			keyLines: map[string]int{"name": 2, "description": 3},
+			endLine:  4,
+		},
+		{
+			name:    "invalid yaml",
+			content: "---\nname: reviewer\ndescription: a: b\n---\nBody\n",
+			wantErr: true,
+			errLine: 3,
+		},
+		{
+			name:    "duplicate key",
+			content: "---\nname: a\nname: b\n---\n",
+			wantErr: true,
+			errLine: 3,
+		},
+		{
+			name:    "not a mapping",
+			content: "---\n- one\n- two\n---\n",
+			wantErr: true,
+			errLine: 2,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			fm := ParseFrontmatterBlock([]byte(tt.content))
+			if tt.wantNil {
+				if fm != nil {
+					t.Errorf("ParseFrontmatterBlock() = %+v, want nil", fm)
+				}
+				return
+			}
+			if fm == nil {
+				t.Fatal("ParseFrontmatterBlock() = nil, want frontmatter")
+			}
+			if (fm.Err != nil) != tt.wantErr {
+				t.Fatalf("Err = %v, wantErr %v", fm.Err, tt.wantErr)
+			}
+			if tt.wantErr && fm.ErrLine != tt.errLine {
+				t.Errorf("ErrLine = %d, want %d", fm.ErrLine, tt.errLine)
+			}
+			for key, line := range tt.keyLines {
+				if got := fm.KeyLine(key); got != line {
+					t.Errorf("KeyLine(%q) = %d, want %d", key, got, line)
+				}
+			}
+			if tt.endLine != 0 && fm.EndLine != tt.endLine {
+				t.Errorf("EndLine = %d, want %d", fm.EndLine, tt.endLine)
+			}
+		})
+	}
+}
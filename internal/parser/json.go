@@ -20,32 +20,147 @@ func (p *JSONParser) Parse(path string, content []byte) (*ParsedFile, error) {
 		return nil, err
 	}
 
-	// Extract top-level keys as sections
-	sections := p.extractSections(data, content)
+	// Build the ordered key tree with source positions
+	keys := newJSONScanner(content).scan()
 
 	return &ParsedFile{
 		Path:     path,
 		Content:  content,
 		FileType: FileTypeJSON,
-		Sections: sections,
+		Sections: sectionsFromKeys(keys),
+		Keys:     keys,
 	}, nil
 }
 
-// extractSections extracts top-level keys as sections
-func (p *JSONParser) extractSections(data interface{}, source []byte) []Section {
-	var sections []Section
+// jsonScanner walks already-validated JSON source and records where each
+// key and value starts and ends
+type jsonScanner struct {
+	src   []byte
+	pos   int
+	lines lineIndex
+}
+
+func newJSONScanner(src []byte) *jsonScanner {
+	return &jsonScanner{src: src, lines: newLineIndex(src)}
+}
+
+// scan returns the root node of the document
+func (s *jsonScanner) scan() *KeyNode {
+	s.skipSpace()
+	return s.value("")
+}
+
+// value scans the value at the current position
+func (s *jsonScanner) value(pointer string) *KeyNode {
+	node := &KeyNode{Pointer: pointer}
+	start := s.pos
+
+	switch s.peek() {
+	case '{':
+		s.pos++
+		for {
+			s.skipSpace()
+			if s.peek() == '}' || s.pos >= len(s.src) {
+				break
+			}
+			keyStart := s.pos
+			s.skipString()
+			keyEnd := s.pos
+
+			var key string
+			_ = json.Unmarshal(s.src[keyStart:keyEnd], &key)
 
-	if obj, ok := data.(map[string]interface{}); ok {
-		line := 1
-		for key := range obj {
-			sections = append(sections, Section{
-				Title:     key,
-				Level:     1,
-				StartLine: line,
-			})
-			line++
+			s.skipSpace()
+			s.pos++ // ':'
+			s.skipSpace()
+
+			child := s.value(childPointer(pointer, key))
+			child.Key = key
+			child.KeyPos = s.position(keyStart, keyEnd)
+			node.Children = append(node.Children, child)
+
+			s.skipSpace()
+			if s.peek() == ',' {
+				s.pos++
+			}
+		}
+		s.pos++ // '}'
+	case '[':
+		s.pos++
+		for i := 0; ; i++ {
+			s.skipSpace()
+			if s.peek() == ']' || s.pos >= len(s.src) {
+				break
+			}
+			child := s.value(childPointer(pointer, indexKey(i)))
+			child.Key = indexKey(i)
+			node.Children = append(node.Children, child)
+
+			s.skipSpace()
+			if s.peek() == ',' {
+				s.pos++
+			}
+		}
+		s.pos++ // ']'
+	case '"':
+		s.skipString()
+	default:
+		for s.pos < len(s.src) && !isJSONDelimiter(s.src[s.pos]) {
+			s.pos++
 		}
 	}
 
-	return sections
+	node.ValuePos = s.position(start, s.pos)
+	return node
+}
+
+// skipString advances past a string literal, honouring escapes
+func (s *jsonScanner) skipString() {
+	s.pos++ // opening quote
+	for s.pos < len(s.src) {
+		switch s.src[s.pos] {
+		case '\\':
+			s.pos += 2
+			continue
+		case '"':
+			s.pos++
+			return
+		}
+		s.pos++
+	}
+}
+
+func (s *jsonScanner) skipSpace() {
+	for s.pos < len(s.src) {
+		switch s.src[s.pos] {
+		case ' ', '\t', '\r', '\n':
+			s.pos++
+		default:
+			return
+		}
+	}
+}
+
+func (s *jsonScanner) peek() byte {
+	if s.pos >= len(s.src) {
+		return 0
+	}
+	return s.src[s.pos]
+}
+
+// position converts a byte range to a 1-based source range; the end column
+// is exclusive
+func (s *jsonScanner) position(start, end int) Position {
+	line, col := s.lines.lineCol(start)
+	endLine, endCol := s.lines.lineCol(end)
+	return Position{Line: line, Column: col, EndLine: endLine, EndColumn: endCol}
+}
+
+// isJSONDelimiter reports whether b ends a number or literal
+func isJSONDelimiter(b byte) bool {
+	switch b {
+	case ',', '}', ']', ' ', '\t', '\r', '\n':
+		return true
+	}
+	return false
 }
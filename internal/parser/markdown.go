@@ -6,6 +6,7 @@ import (
 
 	"github.com/yuin/goldmark"
 	"github.com/yuin/goldmark/ast"
+	"github.com/yuin/goldmark/extension"
 	"github.com/yuin/goldmark/text"
 )
 
@@ -22,11 +23,20 @@ func (p *MarkdownParser) Parse(path string, content []byte) (*ParsedFile, error)
 	// Extract frontmatter if present
 	frontmatter, contentWithoutFrontmatter := ParseFrontmatter(content)
 
-	md := goldmark.New()
+	// Lines before the body, so positions refer to the original file
+	lineOffset := 0
+	if frontmatter != nil {
+		lineOffset = ParseFrontmatterBlock(content).EndLine
+	}
+
+	md := goldmark.New(goldmark.WithExtensions(extension.Table))
 	reader := text.NewReader(contentWithoutFrontmatter)
 	doc := md.Parser().Parse(reader)
 
 	sections := p.extractSections(doc, contentWithoutFrontmatter)
+	offsetSections(sections, lineOffset)
+
+	blocks, links := extractBlocks(doc, contentWithoutFrontmatter, lineOffset)
 
 	return &ParsedFile{
 		Path:        path,
@@ -34,9 +44,20 @@ func (p *MarkdownParser) Parse(path string, content []byte) (*ParsedFile, error)
 		FileType:    FileTypeMarkdown,
 		Sections:    sections,
 		Frontmatter: frontmatter,
+		Blocks:      blocks,
+		Links:       links,
 	}, nil
 }
 
+// offsetSections shifts section lines from body-relative to file-relative
+func offsetSections(sections []Section, offset int) {
+	for i := range sections {
+		sections[i].StartLine += offset
+		sections[i].EndLine += offset
+		offsetSections(sections[i].Subsections, offset)
+	}
+}
+
 // extractSections walks the AST and extracts sections
 func (p *MarkdownParser) extractSections(doc ast.Node, source []byte) []Section {
 	var sections []Section
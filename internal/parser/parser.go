@@ -4,8 +4,6 @@ import (
 	"os"
 	"path/filepath"
 	"strings"
-
-	"gopkg.in/yaml.v3"
 )
 
 // ParsedFile represents a parsed configuration file
@@ -16,6 +14,11 @@ type ParsedFile struct {
 	Category    FileCategory
 	Sections    []Section
 	Frontmatter map[string]interface{} // YAML frontmatter from markdown files
+	Keys        *KeyNode               // Ordered key tree with positions (JSON and YAML files)
+	Blocks      []Block                // Markdown blocks in document order
+	Links       []Link                 // Markdown links and images in document order
+
+	keyIndex map[string]*KeyNode // Keys indexed by JSON pointer, built on first Lookup
 }
 
 // FileType represents the type of configuration file
@@ -34,7 +37,7 @@ type FileCategory int
 const (
 	// FileCategoryUnknown is for files that don't match any known category
 	FileCategoryUnknown FileCategory = iota
-	// FileCategoryConfig is for settings and configuration files (settings.json, mcp.json)
+	// FileCategoryConfig is for settings and configuration files (settings.json, mcp.json, plugin.json)
 	FileCategoryConfig
 	// FileCategoryInstructions is for directive content (CLAUDE.md files)
 	FileCategoryInstructions
@@ -42,6 +45,8 @@ const (
 	FileCategoryCommands
 	// FileCategoryDocumentation is for descriptive content (README.md, docs)
 	FileCategoryDocumentation
+	// FileCategoryOutputStyles is for output styles that replace the system prompt (.claude/output-styles/)
+	FileCategoryOutputStyles
 )
 
 func (c FileCategory) String() string {
@@ -54,6 +59,8 @@ func (c FileCategory) String() string {
 		return "commands"
 	case FileCategoryDocumentation:
 		return "documentation"
+	case FileCategoryOutputStyles:
+		return "output-styles"
 	default:
 		return "unknown"
 	}
@@ -140,6 +147,9 @@ func GetFileCategory(path string) FileCategory {
 		"settings.local.json",
 		"mcp.json",
 		".mcp.json",
+		"plugin.json",
+		"marketplace.json",
+		"hooks.json",
 	}
 	for _, cf := range configFiles {
 		if baseLower == cf {
@@ -152,6 +162,11 @@ func GetFileCategory(path string) FileCategory {
 		return FileCategoryCommands
 	}
 
+	// Output styles - files in .claude/output-styles/ directory
+	if strings.Contains(dir, ".claude/output-styles") || strings.Contains(dir, ".claude\\output-styles") {
+		return FileCategoryOutputStyles
+	}
+
 	// Instructions - CLAUDE.md files
 	if strings.Contains(strings.ToUpper(base), "CLAUDE") {
 		return FileCategoryInstructions
@@ -172,33 +187,9 @@ func GetFileCategory(path string) FileCategory {
 // ParseFrontmatter extracts YAML frontmatter from content between --- delimiters
 // Returns the parsed frontmatter and the remaining content without frontmatter
 func ParseFrontmatter(content []byte) (map[string]interface{}, []byte) {
-	s := string(content)
-
-	// Must start with ---
-	if !strings.HasPrefix(s, "---") {
-		return nil, content
-	}
-
-	// Find the closing ---
-	rest := s[3:]
-	endIdx := strings.Index(rest, "\n---")
-	if endIdx == -1 {
+	fm := ParseFrontmatterBlock(content)
+	if fm == nil || fm.Err != nil {
 		return nil, content
 	}
-
-	// Extract frontmatter YAML
-	frontmatterStr := strings.TrimSpace(rest[:endIdx])
-
-	var frontmatter map[string]interface{}
-	if err := yaml.Unmarshal([]byte(frontmatterStr), &frontmatter); err != nil {
-		return nil, content
-	}
-
-	// Return remaining content after frontmatter
-	remaining := rest[endIdx+4:] // +4 for "\n---"
-	if strings.HasPrefix(remaining, "\n") {
-		remaining = remaining[1:]
-	}
-
-	return frontmatter, []byte(remaining)
+	return fm.Fields, fm.Body
 }
@@ -61,6 +61,13 @@ func TestGetFileCategory(t *testing.T) {
 			expected: FileCategoryCommands,
 		},
 
+		// Output styles
+		{
+			name:     "output style in .claude/output-styles",
+			path:     "/project/.claude/output-styles/concise.md",
+			expected: FileCategoryOutputStyles,
+		},
+
 		// Documentation files
 		{
 			name:     "README.md",
@@ -115,6 +122,7 @@ func TestFileCategoryString(t *testing.T) {
 		{FileCategoryInstructions, "instructions"},
 		{FileCategoryCommands, "commands"},
 		{FileCategoryDocumentation, "documentation"},
+		{FileCategoryOutputStyles, "output-styles"},
 		{FileCategoryUnknown, "unknown"},
 	}
 
@@ -0,0 +1,143 @@
+package parser
+
+import (
+	"sort"
+	"strconv"
+	"strings"
+)
+
+// Position is a 1-based source range
+type Position struct {
+	Line      int
+	Column    int
+	EndLine   int
+	EndColumn int
+}
+
+// KeyNode is a key or array element in a JSON or YAML document, in source order
+type KeyNode struct {
+	// Key is the object key, or the index for array elements
+	Key string
+
+	// Pointer is the JSON pointer (RFC 6901) to this value, e.g. /permissions/allow/0
+	Pointer string
+
+	// KeyPos is the position of the key itself (zero for array elements)
+	KeyPos Position
+
+	// ValuePos is the position of the value
+	ValuePos Position
+
+	// Children are the object members or array elements of the value
+	Children []*KeyNode
+}
+
+// Line returns the line to report for this node: the key if it has one,
+// otherwise the value
+func (n *KeyNode) Line() int {
+	if n.KeyPos.Line > 0 {
+		return n.KeyPos.Line
+	}
+	return n.ValuePos.Line
+}
+
+// Column returns the column to report for this node
+func (n *KeyNode) Column() int {
+	if n.KeyPos.Line > 0 {
+		return n.KeyPos.Column
+	}
+	return n.ValuePos.Column
+}
+
+// Lookup returns the node at a JSON pointer (e.g. /permissions/allow/0).
+// The empty pointer refers to the document root.
+func (f *ParsedFile) Lookup(pointer string) (*KeyNode, bool) {
+	if f == nil || f.Keys == nil {
+		return nil, false
+	}
+	if f.keyIndex == nil {
+		f.keyIndex = make(map[string]*KeyNode)
+		indexKeys(f.Keys, f.keyIndex)
+	}
+	node, ok := f.keyIndex[pointer]
+	return node, ok
+}
+
+// LineOf returns the line of the node at pointer, or 1 if it does not exist
+func (f *ParsedFile) LineOf(pointer string) int {
+	if node, ok := f.Lookup(pointer); ok {
+		return node.Line()
+	}
+	return 1
+}
+
+// indexKeys adds node and its descendants to index by pointer
+func indexKeys(node *KeyNode, index map[string]*KeyNode) {
+	index[node.Pointer] = node
+	for _, child := range node.Children {
+		indexKeys(child, index)
+	}
+}
+
+// JSONPointer joins path segments into a JSON pointer, escaping ~ and /
+func JSONPointer(segments ...string) string {
+	var sb strings.Builder
+	for _, s := range segments {
+		sb.WriteString("/")
+		s = strings.ReplaceAll(s, "~", "~0")
+		sb.WriteString(strings.ReplaceAll(s, "/", "~1"))
+	}
+	return sb.String()
+}
+
+// childPointer returns the pointer to a member or element of parent
+func childPointer(parent, key string) string {
+	return parent + JSONPointer(key)
+}
+
+// indexKey returns the key for an array element
+func indexKey(i int) string {
+	return strconv.Itoa(i)
+}
+
+// sectionsFromKeys converts the top-level members of a document to sections
+func sectionsFromKeys(root *KeyNode) []Section {
+	var sections []Section
+	if root == nil {
+		return sections
+	}
+	for _, child := range root.Children {
+		if child.KeyPos.Line == 0 {
+			continue // Top-level arrays have no named sections
+		}
+		sections = append(sections, Section{
+			Title:     child.Key,
+			Level:     1,
+			StartLine: child.KeyPos.Line,
+			EndLine:   child.ValuePos.EndLine,
+		})
+	}
+	return sections
+}
+
+// lineIndex maps byte offsets in a source to line numbers
+type lineIndex []int
+
+// newLineIndex records the offset at which each line of src starts
+func newLineIndex(src []byte) lineIndex {
+	starts := lineIndex{0}
+	for i, b := range src {
+		if b == '\n' {
+			starts = append(starts, i+1)
+		}
+	}
+	return starts
+}
+
+// lineCol returns the 1-based line and byte column of an offset
+func (li lineIndex) lineCol(offset int) (int, int) {
+	line := sort.Search(len(li), func(i int) bool {
+		return li[i] > offset
+	})
+	return line, offset - li[line-1] + 1
+}
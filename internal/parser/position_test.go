@@ -0,0 +1,108 @@
+package parser
+
+import (
+	"testing"
+)
+
+func TestJSONParserPositions(t *testing.T) {
+	content := `{
+  "permissions": {
+    "allow": [
+      "Read",
+      "Bash(*)"
+    ]
+  },
+  "a/b": {"x~y": true},
+  "model": "sonnet"
+}`
+
+	parsed, err := (&JSONParser{}).Parse("settings.json", []byte(content))
+	if err != nil {
+		t.Fatalf("Parse() error: %v", err)
+	}
+
+	tests := []struct {
+		pointer string
+		line    int
+		column  int
+	}{
+		{"/permissions", 2, 3},
+		{"/permissions/allow", 3, 5},
+		{"/permissions/allow/1", 5, 7},
+		{JSONPointer("a/b", "x~y"), 8, 11},
+		{"/model", 9, 3},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.pointer, func(t *testing.T) {
+			node, ok := parsed.Lookup(tt.pointer)
+			if !ok {
+				t.Fatalf("Lookup(%q) not found", tt.pointer)
+			}
+			if node.Line() != tt.line || node.Column() != tt.column {
+				t.Errorf("Lookup(%q) = %d:%d, want %d:%d", tt.pointer, node.Line(), node.Column(), tt.line, tt.column)
+			}
+		})
+	}
+
+	if node, _ := parsed.Lookup("/permissions"); node.ValuePos.EndLine != 7 {
+		t.Errorf("/permissions EndLine = %d, want 7", node.ValuePos.EndLine)
+	}
+
+	var titles []string
+	for _, section := range parsed.Sections {
+		titles = append(titles, section.Title)
+	}
+	if len(titles) != 3 || titles[0] != "permissions" || titles[1] != "a/b" || titles[2] != "model" {
+		t.Errorf("Sections = %v, want [permissions a/b model] in source order", titles)
+	}
+
+	if _, ok := parsed.Lookup("/missing"); ok {
+		t.Error("Lookup(/missing) found a node")
+	}
+	if line := parsed.LineOf("/missing"); line != 1 {
+		t.Errorf("LineOf(/missing) = %d, want 1", line)
+	}
+}
+
+func TestYAMLParserPositions(t *testing.T) {
+	content := `name: claude-code
+markers:
+  high_priority:
+    - "IMPORTANT"
+    - CRITICAL
+entrypoints: [CLAUDE.md]
+`
+
+	parsed, err := (&YAMLParser{}).Parse("agent.yaml", []byte(content))
+	if err != nil {
+		t.Fatalf("Parse() error: %v", err)
+	}
+
+	tests := []struct {
+		pointer string
+		line    int
+		column  int
+	}{
+		{"/name", 1, 1},
+		{"/markers/high_priority", 3, 3},
+		{"/markers/high_priority/1", 5, 7},
+		{"/entrypoints/0", 6, 15},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.pointer, func(t *testing.T) {
+			node, ok := parsed.Lookup(tt.pointer)
+			if !ok {
+				t.Fatalf("Lookup(%q) not found", tt.pointer)
+			}
+			if node.Line() != tt.line || node.Column() != tt.column {
+				t.Errorf("Lookup(%q) = %d:%d, want %d:%d", tt.pointer, node.Line(), node.Column(), tt.line, tt.column)
+			}
+		})
+	}
+
+	if len(parsed.Sections) != 3 || parsed.Sections[1].Title != "markers" || parsed.Sections[1].StartLine != 2 || parsed.Sections[1].EndLine != 5 {
+		t.Errorf("Sections = %+v, want markers at lines 2-5", parsed.Sections)
+	}
+}
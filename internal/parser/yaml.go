@@ -16,37 +16,69 @@ func (p *YAMLParser) CanParse(path string) bool {
 // Parse parses a YAML file
 func (p *YAMLParser) Parse(path string, content []byte) (*ParsedFile, error) {
 	// Validate YAML
-	var data interface{}
-	if err := yaml.Unmarshal(content, &data); err != nil {
+	var doc yaml.Node
+	if err := yaml.Unmarshal(content, &doc); err != nil {
 		return nil, err
 	}
 
-	// Extract top-level keys as sections
-	sections := p.extractSections(data)
+	// Build the ordered key tree with source positions
+	var keys *KeyNode
+	if len(doc.Content) > 0 {
+		keys = yamlKeyNode(doc.Content[0], "")
+	}
 
 	return &ParsedFile{
 		Path:     path,
 		Content:  content,
 		FileType: FileTypeYAML,
-		Sections: sections,
+		Sections: sectionsFromKeys(keys),
+		Keys:     keys,
 	}, nil
 }
 
-// extractSections extracts top-level keys as sections
-func (p *YAMLParser) extractSections(data interface{}) []Section {
-	var sections []Section
-
-	if obj, ok := data.(map[string]interface{}); ok {
-		line := 1
-		for key := range obj {
-			sections = append(sections, Section{
-				Title:     key,
-				Level:     1,
-				StartLine: line,
-			})
-			line++
+// yamlKeyNode converts a YAML node to a KeyNode. yaml.v3 only records where
+// a node starts, so the end is taken from its last descendant.
+func yamlKeyNode(n *yaml.Node, pointer string) *KeyNode {
+	if n.Kind == yaml.AliasNode && n.Alias != nil {
+		n = n.Alias
+	}
+
+	node := &KeyNode{Pointer: pointer}
+
+	switch n.Kind {
+	case yaml.MappingNode:
+		for i := 0; i+1 < len(n.Content); i += 2 {
+			key, value := n.Content[i], n.Content[i+1]
+			child := yamlKeyNode(value, childPointer(pointer, key.Value))
+			child.Key = key.Value
+			child.KeyPos = yamlPosition(key)
+			node.Children = append(node.Children, child)
+		}
+	case yaml.SequenceNode:
+		for i, item := range n.Content {
+			child := yamlKeyNode(item, childPointer(pointer, indexKey(i)))
+			child.Key = indexKey(i)
+			node.Children = append(node.Children, child)
 		}
 	}
 
-	return sections
+	node.ValuePos = yamlPosition(n)
+	if len(node.Children) > 0 {
+		last := node.Children[len(node.Children)-1].ValuePos
+		node.ValuePos.EndLine, node.ValuePos.EndColumn = last.EndLine, last.EndColumn
+	}
+	return node
+}
+
+// yamlPosition returns the range of a node. The end is approximate for
+// scalars containing escapes and block scalars, which end on their first line.
+func yamlPosition(n *yaml.Node) Position {
+	end := n.Column
+	if n.Kind == yaml.ScalarNode {
+		end += len(n.Value)
+		if n.Style&(yaml.DoubleQuotedStyle|yaml.SingleQuotedStyle) != 0 {
+			end += 2
+		}
+	}
+	return Position{Line: n.Line, Column: n.Column, EndLine: n.Line, EndColumn: end}
 }
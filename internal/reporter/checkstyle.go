@@ -0,0 +1,88 @@
+package reporter
+
+import (
+	"encoding/xml"
+	"io"
+
+	"github.com/pthm/cclint/internal/rules"
+)
+
+// CheckstyleReporter outputs results as Checkstyle XML, which Jenkins,
+// GitLab and most CI code quality plugins can read
+type CheckstyleReporter struct {
+	w       io.Writer
+	baseDir string
+}
+
+// NewCheckstyleReporter creates a new Checkstyle reporter. File paths are
+// made relative to baseDir.
+func NewCheckstyleReporter(w io.Writer, baseDir string) *CheckstyleReporter {
+	return &CheckstyleReporter{w: w, baseDir: baseDir}
+}
+
+// CheckstyleOutput is the checkstyle root element
+type CheckstyleOutput struct {
+	XMLName xml.Name         `xml:"checkstyle"`
+	Version string           `xml:"version,attr"`
+	Files   []CheckstyleFile `xml:"file"`
+}
+
+// CheckstyleFile holds the issues in one file
+type CheckstyleFile struct {
+	Name   string            `xml:"name,attr"`
+	Errors []CheckstyleError `xml:"error"`
+}
+
+// CheckstyleError is a single issue
+type CheckstyleError struct {
+	Line     int    `xml:"line,attr,omitempty"`
+	Column   int    `xml:"column,attr,omitempty"`
+	Severity string `xml:"severity,attr"`
+	Message  string `xml:"message,attr"`
+	Source   string `xml:"source,attr"`
+}
+
+// Report outputs issues grouped by file, in the order files first appear
+func (r *CheckstyleReporter) Report(issues []rules.Issue) error {
+	output := CheckstyleOutput{Version: "4.3", Files: []CheckstyleFile{}}
+
+	fileIndex := make(map[string]int)
+	for _, issue := range issues {
+		i, ok := fileIndex[issue.File]
+		if !ok {
+			i = len(output.Files)
+			fileIndex[issue.File] = i
+			output.Files = append(output.Files, CheckstyleFile{Name: relativePath(r.baseDir, issue.File)})
+		}
+		output.Files[i].Errors = append(output.Files[i].Errors, CheckstyleError{
+			Line:     issue.Line,
+			Column:   issue.Column,
+			Severity: checkstyleSeverity(issue.Severity),
+			Message:  issue.Message,
+			Source:   "cclint." + issue.Rule,
+		})
+	}
+
+	if _, err := io.WriteString(r.w, xml.Header); err != nil {
+		return err
+	}
+	encoder := xml.NewEncoder(r.w)
+	encoder.Indent("", "  ")
+	if err := encoder.Encode(output); err != nil {
+		return err
+	}
+	_, err := io.WriteString(r.w, "\n")
+	return err
+}
+
+// checkstyleSeverity maps a severity to a Checkstyle severity
+func checkstyleSeverity(s rules.Severity) string {
+	switch s {
+	case rules.Error:
+		return "error"
+	case rules.Warning:
+		return "warning"
+	default:
+		return "info"
+	}
+}
@@ -0,0 +1,44 @@
+package reporter
+
+import (
+	"bytes"
+	"encoding/xml"
+	"path/filepath"
+	"testing"
+
+	"github.com/pthm/cclint/internal/rules"
+)
+
+func TestCheckstyleReporter(t *testing.T) {
+	base := filepath.FromSlash("/work/repo")
+	issues := []rules.Issue{
+		{Rule: "broken-refs", Severity: rules.Error, Message: `Missing "a" & <b>`, File: filepath.Join(base, "CLAUDE.md"), Line: 2, Column: 7},
+		{Rule: "secrets", Severity: rules.Warning, Message: "Key", File: filepath.Join(base, ".claude", "settings.json"), Line: 3},
+		{Rule: "verbosity", Severity: rules.Info, Message: "Long", File: filepath.Join(base, "CLAUDE.md")},
+	}
+
+	var buf bytes.Buffer
+	if err := NewCheckstyleReporter(&buf, base).Report(issues); err != nil {
+		t.Fatal(err)
+	}
+
+	var output CheckstyleOutput
+	if err := xml.Unmarshal(buf.Bytes(), &output); err != nil {
+		t.Fatalf("invalid XML: %v\n%s", err, buf.String())
+	}
+	if len(output.Files) != 2 || output.Files[0].Name != "CLAUDE.md" || output.Files[1].Name != ".claude/settings.json" {
+		t.Fatalf("files = %+v", output.Files)
+	}
+
+	got := output.Files[0].Errors
+	want := []CheckstyleError{
+		{Line: 2, Column: 7, Severity: "error", Message: `Missing "a" & <b>`, Source: "cclint.broken-refs"},
+		{Severity: "info", Message: "Long", Source: "cclint.verbosity"},
+	}
+	if len(got) != len(want) || got[0] != want[0] || got[1] != want[1] {
+		t.Errorf("errors = %+v, want %+v", got, want)
+	}
+	if output.Files[1].Errors[0].Severity != "warning" {
+		t.Errorf("warning severity = %s", output.Files[1].Errors[0].Severity)
+	}
+}
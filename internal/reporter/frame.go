@@ -0,0 +1,348 @@
+package reporter
+
+import (
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"unicode"
+	"unicode/utf8"
+
+	"github.com/charmbracelet/lipgloss"
+	"github.com/pthm/cclint/internal/rules"
+	"github.com/pthm/cclint/internal/ui"
+)
+
+const (
+	// maxFrameLines caps how many lines of a multi-line span a frame shows
+	maxFrameLines = 6
+
+	// maxFrameWidth is the display width long source lines are cut to
+	maxFrameWidth = 160
+
+	// maxRelatedFrames caps the secondary locations shown per issue
+	maxRelatedFrames = 3
+)
+
+// frameGlyphs are the characters code frames are drawn with
+type frameGlyphs struct {
+	gutter    string // Separates line numbers from source
+	span      string // Marks the lines of a multi-line span
+	primary   string // Underlines the issue's own location
+	secondary string // Underlines related locations
+	related   string // Introduces a related location in another place
+	ellipsis  string // Ends a line cut to maxFrameWidth
+}
+
+var (
+	unicodeGlyphs = frameGlyphs{gutter: "│", span: "┃", primary: "^", secondary: "-", related: ":::", ellipsis: "…"}
+	asciiGlyphs   = frameGlyphs{gutter: "|", span: ">", primary: "^", secondary: "-", related: ":::", ellipsis: "..."}
+)
+
+// frameSpan is a stretch of source to show, with the columns to underline.
+// Columns are 1-based byte offsets; endColumn is just past the span.
+type frameSpan struct {
+	file      string
+	line      int
+	endLine   int
+	column    int
+	endColumn int
+	label     string
+	primary   bool
+}
+
+// framePrinter renders rustc-style code frames: source lines with a line
+// number gutter and an underline beneath the offending columns
+type framePrinter struct {
+	w       io.Writer
+	styles  *ui.Styles
+	glyphs  frameGlyphs
+	sources map[string][]string // Lines per file; nil if unreadable
+}
+
+func newFramePrinter(w io.Writer, styles *ui.Styles) *framePrinter {
+	glyphs := asciiGlyphs
+	if styles.Enabled() {
+		glyphs = unicodeGlyphs
+	}
+	return &framePrinter{w: w, styles: styles, glyphs: glyphs, sources: make(map[string][]string)}
+}
+
+// lines returns the lines of a file, reading each file once
+func (p *framePrinter) lines(file string) []string {
+	if lines, ok := p.sources[file]; ok {
+		return lines
+	}
+	var lines []string
+	if content, err := os.ReadFile(file); err == nil {
+		lines = strings.Split(string(content), "\n")
+		for i, line := range lines {
+			lines[i] = strings.TrimSuffix(line, "\r")
+		}
+	}
+	p.sources[file] = lines
+	return lines
+}
+
+// redact masks the secrets of sensitive issues in the cached source, keeping
+// their length so other issues' columns on the same lines stay correct
+func (p *framePrinter) redact(issues []rules.Issue) {
+	for _, issue := range issues {
+		if !issue.Sensitive || issue.Column < 1 || issue.EndColumn <= issue.Column {
+			continue
+		}
+		lines := p.lines(issue.File)
+		if issue.Line < 1 || issue.Line > len(lines) {
+			continue
+		}
+		line := lines[issue.Line-1]
+		from, to := min(issue.Column-1, len(line)), min(issue.EndColumn-1, len(line))
+		lines[issue.Line-1] = line[:from] + maskSecret(line[from:to]) + line[to:]
+	}
+}
+
+// maskSecret redacts a secret like rules.RedactSecret, but keeps its length
+func maskSecret(secret string) string {
+	redacted := rules.RedactSecret(secret)
+	if redacted == secret {
+		return secret
+	}
+	kept := strings.TrimRight(redacted, "*")
+	return kept + strings.Repeat("*", len(secret)-len(kept))
+}
+
+// printIssue prints the frames for an issue: its own location, then related
+// locations and the places its fix edits, then the fix description. It
+// reports false if the issue's location could not be shown.
+func (p *framePrinter) printIssue(issue rules.Issue, indent string) bool {
+	// A secret that could not be located cannot be masked
+	if issue.Sensitive && (issue.Column < 1 || issue.EndColumn <= issue.Column) {
+		return false
+	}
+
+	primary := issueSpan(issue)
+	if !p.printFrame(primary, indent, severityStyle(p.styles, issue.Severity)) {
+		return false
+	}
+
+	related := relatedSpans(issue, primary)
+	for i, span := range related {
+		if i == maxRelatedFrames {
+			fmt.Fprintf(p.w, "%s%s\n", indent, p.styles.Subheader.Render(
+				fmt.Sprintf("%s and %d more", p.glyphs.related, len(related)-maxRelatedFrames)))
+			break
+		}
+		fmt.Fprintf(p.w, "%s%s %s\n", indent, p.styles.Subheader.Render(p.glyphs.related),
+			p.styles.Path.Render(spanPosition(span, issue.File)))
+		p.printFrame(span, indent, p.styles.Info)
+	}
+
+	if issue.Fix != nil && issue.Fix.Description != "" {
+		fmt.Fprintf(p.w, "%s%s %s\n", indent, p.styles.Subheader.Render("= fix:"), issue.Fix.Description)
+	}
+	return true
+}
+
+// printFrame prints one span. It reports false, printing nothing, if the
+// span's lines cannot be read.
+func (p *framePrinter) printFrame(span frameSpan, indent string, style lipgloss.Style) bool {
+	lines := p.lines(span.file)
+	if span.line < 1 || span.line > len(lines) {
+		return false
+	}
+
+	last := min(max(span.endLine, span.line), len(lines))
+	for last > span.line && strings.TrimSpace(lines[last-1]) == "" {
+		last--
+	}
+	multiLine := last > span.line
+	shown := min(last, span.line+maxFrameLines-1)
+
+	width := len(strconv.Itoa(shown))
+	glyph := p.glyphs.secondary
+	if span.primary {
+		glyph = p.glyphs.primary
+	}
+	gutter := func(number string) string {
+		return indent + p.styles.Subheader.Render(fmt.Sprintf("%*s %s", width, number, p.glyphs.gutter))
+	}
+
+	fmt.Fprintln(p.w, gutter(""))
+	for n := span.line; n <= shown; n++ {
+		text := p.cut(lines[n-1])
+		if multiLine {
+			text = style.Render(p.glyphs.span) + " " + visible(text)
+		} else {
+			text = visible(text)
+		}
+		fmt.Fprintln(p.w, strings.TrimRight(gutter(strconv.Itoa(n))+" "+text, " "))
+	}
+	if shown < last {
+		fmt.Fprintln(p.w, gutter("")+" "+p.styles.Subheader.Render(fmt.Sprintf("%s %d more lines", p.glyphs.ellipsis, last-shown)))
+	}
+
+	if multiLine {
+		if span.label != "" {
+			fmt.Fprintln(p.w, gutter("")+" "+style.Render(p.glyphs.span+" "+span.label))
+		}
+		return true
+	}
+
+	text := p.cut(lines[span.line-1])
+	from, to, ok := underlineRange(text, span)
+	if !ok {
+		return true
+	}
+	under := strings.Repeat(glyph, max(lipgloss.Width(visible(text[from:to])), 1))
+	if span.label != "" {
+		under += " " + span.label
+	}
+	fmt.Fprintln(p.w, gutter("")+" "+padding(visible(text[:from]))+style.Render(under))
+	return true
+}
+
+// cut shortens a line to maxFrameWidth, keeping it valid UTF-8
+func (p *framePrinter) cut(line string) string {
+	if lipgloss.Width(line) <= maxFrameWidth {
+		return line
+	}
+	width := 0
+	for i, r := range line {
+		width += lipgloss.Width(string(r))
+		if width > maxFrameWidth {
+			return line[:i] + p.glyphs.ellipsis
+		}
+	}
+	return line
+}
+
+// underlineRange returns the byte range of text to underline for a
+// single-line span. Without a column, a labelled span underlines the whole
+// line and an unlabelled one is not underlined: many rules report line 1 to
+// mean the file as a whole.
+func underlineRange(text string, span frameSpan) (int, int, bool) {
+	if span.column < 1 {
+		trimmed := strings.TrimSpace(text)
+		if span.label == "" || trimmed == "" {
+			return 0, 0, false
+		}
+		from := strings.Index(text, trimmed)
+		return from, from + len(trimmed), true
+	}
+
+	from := min(span.column-1, len(text))
+	to := from
+	if span.endColumn > span.column {
+		to = min(span.endColumn-1, len(text))
+	} else if from < len(text) {
+		_, size := utf8.DecodeRuneInString(text[from:])
+		to = from + size
+	}
+	return from, to, true
+}
+
+// issueSpan is the span of the issue's own location. Without an end column,
+// a fix replacing text that starts at the issue's column supplies one.
+func issueSpan(issue rules.Issue) frameSpan {
+	span := frameSpan{
+		file:      issue.File,
+		line:      issue.Line,
+		endLine:   issue.EndLine,
+		column:    issue.Column,
+		endColumn: issue.EndColumn,
+		primary:   true,
+	}
+	if span.endColumn == 0 && span.column > 0 && issue.Fix != nil {
+		for _, edit := range issue.Fix.Edits {
+			if edit.IsRange() && edit.File == issue.File && edit.StartLine == issue.Line &&
+				edit.EndLine == issue.Line && edit.StartColumn == issue.Column {
+				span.endColumn = edit.EndColumn
+				break
+			}
+		}
+	}
+	return span
+}
+
+// relatedSpans are the issue's related locations, then the fix edits that
+// fall outside the primary span
+func relatedSpans(issue rules.Issue, primary frameSpan) []frameSpan {
+	var spans []frameSpan
+	for _, loc := range issue.Related {
+		spans = append(spans, frameSpan{
+			file:      loc.File,
+			line:      loc.Line,
+			column:    loc.Column,
+			endColumn: loc.EndColumn,
+			label:     loc.Message,
+		})
+	}
+
+	if issue.Fix == nil {
+		return spans
+	}
+	primaryEnd := max(primary.endLine, primary.line)
+	for _, edit := range issue.Fix.Edits {
+		if edit.File == primary.file && edit.StartLine >= primary.line && edit.StartLine <= primaryEnd {
+			continue
+		}
+		span := frameSpan{file: edit.File, line: edit.StartLine, endLine: edit.EndLine, label: "changed by the fix"}
+		if edit.IsRange() && edit.EndLine == edit.StartLine {
+			span.column, span.endColumn = edit.StartColumn, edit.EndColumn
+		}
+		spans = append(spans, span)
+	}
+	return spans
+}
+
+// spanPosition formats a span's position, naming files other than the
+// issue's own relative to it
+func spanPosition(span frameSpan, issueFile string) string {
+	name := filepath.Base(span.file)
+	if span.file != issueFile {
+		if rel, err := filepath.Rel(filepath.Dir(issueFile), span.file); err == nil {
+			name = rel
+		}
+	}
+	if span.column > 0 {
+		return fmt.Sprintf("%s:%d:%d", name, span.line, span.column)
+	}
+	return fmt.Sprintf("%s:%d", name, span.line)
+}
+
+// visible makes invisible characters in source text visible: format
+// characters (zero-width and bidi controls) are shown as <U+XXXX>, and other
+// control characters except tabs as U+FFFD, so they neither hide from the
+// reader nor affect the terminal
+func visible(s string) string {
+	var b strings.Builder
+	for _, r := range s {
+		switch {
+		case r == '\t':
+			b.WriteRune(r)
+		case unicode.Is(unicode.Cf, r):
+			fmt.Fprintf(&b, "<U+%04X>", r)
+		case unicode.IsControl(r):
+			b.WriteRune(utf8.RuneError)
+		default:
+			b.WriteRune(r)
+		}
+	}
+	return b.String()
+}
+
+// padding is whitespace as wide as s, keeping tabs so an underline lines up
+// with the source above it
+func padding(s string) string {
+	var b strings.Builder
+	for _, r := range s {
+		if r == '\t' {
+			b.WriteRune(r)
+			continue
+		}
+		b.WriteString(strings.Repeat(" ", lipgloss.Width(string(r))))
+	}
+	return b.String()
+}
@@ -0,0 +1,82 @@
+package reporter
+
+import (
+	"fmt"
+	"io"
+	"strings"
+
+	"github.com/pthm/cclint/internal/rules"
+)
+
+// GitHubReporter outputs results as GitHub Actions workflow commands, which
+// show up as annotations on the pull request diff
+type GitHubReporter struct {
+	w       io.Writer
+	baseDir string
+}
+
+// NewGitHubReporter creates a new GitHub Actions reporter. File paths are
+// made relative to baseDir, which should be the workspace root.
+func NewGitHubReporter(w io.Writer, baseDir string) *GitHubReporter {
+	return &GitHubReporter{w: w, baseDir: baseDir}
+}
+
+// Report outputs one annotation per issue, and fails if there are errors
+func (r *GitHubReporter) Report(issues []rules.Issue) error {
+	for _, issue := range issues {
+		props := []string{"file=" + escapeGitHubProperty(relativePath(r.baseDir, issue.File))}
+		if issue.Line > 0 {
+			props = append(props, fmt.Sprintf("line=%d", issue.Line))
+			if issue.Column > 0 {
+				props = append(props, fmt.Sprintf("col=%d", issue.Column))
+			}
+			if issue.EndLine > issue.Line {
+				props = append(props, fmt.Sprintf("endLine=%d", issue.EndLine))
+			} else if issue.Column > 0 && issue.EndColumn > issue.Column {
+				// EndColumn is on Line, so it only bounds single-line issues
+				props = append(props, fmt.Sprintf("endColumn=%d", issue.EndColumn))
+			}
+		}
+		props = append(props, "title="+escapeGitHubProperty("cclint: "+issue.Rule))
+
+		if _, err := fmt.Fprintf(r.w, "::%s %s::%s\n",
+			githubCommand(issue.Severity), strings.Join(props, ","), escapeGitHubData(issue.Message)); err != nil {
+			return err
+		}
+	}
+
+	s := ComputeSummary(issues)
+	fmt.Fprintf(r.w, "cclint: %d issues (%d errors, %d warnings, %d suggestions, %d info) in %d files\n",
+		s.TotalIssues, s.Errors, s.Warnings, s.Suggestions, s.Info, s.Files)
+
+	if hasErrors(issues) {
+		return fmt.Errorf("lint errors found")
+	}
+	return nil
+}
+
+// githubCommand maps a severity to a workflow command
+func githubCommand(s rules.Severity) string {
+	switch s {
+	case rules.Error:
+		return "error"
+	case rules.Warning:
+		return "warning"
+	default:
+		return "notice"
+	}
+}
+
+// escapeGitHubData escapes a workflow command message
+func escapeGitHubData(s string) string {
+	s = strings.ReplaceAll(s, "%", "%25")
+	s = strings.ReplaceAll(s, "\r", "%0D")
+	return strings.ReplaceAll(s, "\n", "%0A")
+}
+
+// escapeGitHubProperty escapes a workflow command property value
+func escapeGitHubProperty(s string) string {
+	s = escapeGitHubData(s)
+	s = strings.ReplaceAll(s, ":", "%3A")
+	return strings.ReplaceAll(s, ",", "%2C")
+}
@@ -0,0 +1,38 @@
+package reporter
+
+import (
+	"bytes"
+	"path/filepath"
+	"testing"
+
+	"github.com/pthm/cclint/internal/rules"
+)
+
+func TestGitHubReporter(t *testing.T) {
+	base := filepath.FromSlash("/work/repo")
+	issues := []rules.Issue{
+		{Rule: "broken-refs", Severity: rules.Error, Message: "Referenced file not found: 50% done\nnext", File: filepath.Join(base, "CLAUDE.md"), Line: 2, Column: 7, EndColumn: 19},
+		{Rule: "secrets", Severity: rules.Warning, Message: "Key", File: filepath.Join(base, "a,b:c.md"), Line: 3, Column: 2, EndColumn: 9, EndLine: 5},
+		{Rule: "verbosity/long-sentences", Severity: rules.Suggestion, Message: "Long", File: filepath.Join(base, "docs", "x.md")},
+	}
+
+	var buf bytes.Buffer
+	err := NewGitHubReporter(&buf, base).Report(issues)
+	if err == nil {
+		t.Error("expected an error when there are lint errors")
+	}
+
+	// EndColumn is only reported for single-line issues
+	want := "::error file=CLAUDE.md,line=2,col=7,endColumn=19,title=cclint%3A broken-refs::Referenced file not found: 50%25 done%0Anext\n" +
+		"::warning file=a%2Cb%3Ac.md,line=3,col=2,endLine=5,title=cclint%3A secrets::Key\n" +
+		"::notice file=docs/x.md,title=cclint%3A verbosity/long-sentences::Long\n" +
+		"cclint: 3 issues (1 errors, 1 warnings, 1 suggestions, 0 info) in 3 files\n"
+	if buf.String() != want {
+		t.Errorf("output =\n%s\nwant\n%s", buf.String(), want)
+	}
+
+	buf.Reset()
+	if err := NewGitHubReporter(&buf, base).Report(issues[1:]); err != nil {
+		t.Errorf("unexpected error without lint errors: %v", err)
+	}
+}
@@ -0,0 +1,138 @@
+package reporter
+
+import (
+	_ "embed"
+	"html/template"
+	"io"
+
+	"github.com/pthm/cclint/internal/analyzer"
+	"github.com/pthm/cclint/internal/rules"
+	"github.com/pthm/cclint/internal/version"
+)
+
+//go:embed templates/report.html
+var htmlTemplate string
+
+// reportTemplate renders self-contained HTML reports, with CSS and JS inline
+var reportTemplate = template.Must(template.New("report").Parse(htmlTemplate))
+
+// HTMLReporter outputs results as a self-contained HTML page with sortable
+// issue tables and the scope tree
+type HTMLReporter struct {
+	w    io.Writer
+	data ReportData
+}
+
+// NewHTMLReporter creates a new HTML reporter
+func NewHTMLReporter(w io.Writer, data ReportData) *HTMLReporter {
+	return &HTMLReporter{w: w, data: data}
+}
+
+// htmlReport is the data the HTML template renders
+type htmlReport struct {
+	Root       string
+	Version    string
+	Summary    Summary
+	Severities []string
+	Issues     []htmlIssue
+	Scopes     []htmlScope
+}
+
+// htmlIssue is an issue row
+type htmlIssue struct {
+	File     string
+	Line     int
+	Position string
+	Severity string
+	Rank     int // Sorts errors first
+	Rule     string
+	Message  string
+	HasFix   bool
+}
+
+// htmlScope is a node of the scope tree
+type htmlScope struct {
+	Name       string
+	Type       string
+	Entrypoint string
+	Tokens     int
+	Budget     int
+	Percent    int
+	Over       bool
+	Files      []htmlFile
+	Children   []htmlScope
+}
+
+// BarWidth returns the budget bar fill, capped at 100%
+func (s htmlScope) BarWidth() int {
+	return min(s.Percent, 100)
+}
+
+// htmlFile is a file in a scope
+type htmlFile struct {
+	Path   string
+	Tokens int
+}
+
+// Report outputs the HTML page
+func (r *HTMLReporter) Report(issues []rules.Issue) error {
+	report := htmlReport{
+		Root:       r.data.RootPath,
+		Version:    version.Short(),
+		Summary:    ComputeSummary(issues),
+		Severities: []string{"error", "warning", "suggestion", "info"},
+		Issues:     []htmlIssue{},
+	}
+
+	files, byFile := issuesByFile(issues)
+	for _, file := range files {
+		for _, issue := range byFile[file] {
+			report.Issues = append(report.Issues, htmlIssue{
+				File:     relativePath(r.data.RootPath, issue.File),
+				Line:     issue.Line,
+				Position: issuePosition(issue),
+				Severity: issue.Severity.String(),
+				Rank:     int(rules.Error - issue.Severity),
+				Rule:     issue.Rule,
+				Message:  issue.Message,
+				HasFix:   issue.Fix != nil,
+			})
+		}
+	}
+
+	report.Scopes = r.htmlScopes(r.data.Scopes)
+
+	return reportTemplate.Execute(r.w, report)
+}
+
+// htmlScopes converts scopes and their children for the template
+func (r *HTMLReporter) htmlScopes(scopes []*analyzer.ContextScope) []htmlScope {
+	var result []htmlScope
+	for _, scope := range scopes {
+		b := scopeBudget{Scope: scope, Tokens: scope.EstimatedTokens()}
+		if r.data.AgentConfig != nil {
+			b.Budget = r.data.AgentConfig.TokenBudget(scope.Type.String())
+		}
+
+		s := htmlScope{
+			Name:     scope.Name,
+			Type:     scope.Type.String(),
+			Tokens:   b.Tokens,
+			Budget:   b.Budget,
+			Percent:  b.Percent(),
+			Over:     b.Over(),
+			Children: r.htmlScopes(scope.Children),
+		}
+		if scope.Entrypoint != "" && scope.Type != analyzer.ScopeTypeMain {
+			s.Entrypoint = relativePath(r.data.RootPath, scope.Entrypoint)
+		}
+		for _, node := range scope.Nodes {
+			s.Files = append(s.Files, htmlFile{
+				Path:   relativePath(r.data.RootPath, node.Path),
+				Tokens: analyzer.EstimateTokens(node.Content),
+			})
+		}
+		result = append(result, s)
+	}
+	return result
+}
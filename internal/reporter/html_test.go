@@ -0,0 +1,55 @@
+package reporter
+
+import (
+	"bytes"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/pthm/cclint/internal/rules"
+)
+
+func TestHTMLReporter(t *testing.T) {
+	root := filepath.FromSlash("/work/repo")
+	issues := []rules.Issue{
+		{Rule: "broken-refs", Severity: rules.Error, Message: "Missing <script>alert(1)</script>", File: filepath.Join(root, "CLAUDE.md"), Line: 2, Column: 7,
+			Fix: &rules.Fix{Description: "Change reference"}},
+		{Rule: "verbosity", Severity: rules.Info, Message: "Long", File: filepath.Join(root, "AGENTS.md")},
+	}
+
+	var buf bytes.Buffer
+	if err := NewHTMLReporter(&buf, testReportData(root)).Report(issues); err != nil {
+		t.Fatal(err)
+	}
+	out := buf.String()
+
+	for _, want := range []string{
+		"<!DOCTYPE html>",
+		"<style>",
+		"<script>",
+		`<tr data-severity="error">`,
+		`<td class="num" data-value="2">2:7</td>`,
+		`<td class="sev-error" data-value="0">error</td>`,
+		`<td class="sev-info" data-value="3">info</td>`,
+		"Missing &lt;script&gt;alert(1)&lt;/script&gt;",
+		`<span class="ok">auto-fix</span>`,
+		// Scope tree with budgets; the command is over budget
+		"<strong>main</strong>",
+		"1000 tokens of 2000",
+		`<span class="path muted">.claude/commands/deploy.md</span>`,
+		`<span class="bar over" title="200% of budget"><span style="width: 100%"></span></span>`,
+	} {
+		if !strings.Contains(out, want) {
+			t.Errorf("output missing %q", want)
+		}
+	}
+	if strings.Contains(out, "<script>alert(1)") {
+		t.Error("issue message was not escaped")
+	}
+	// Self-contained: no external resources
+	for _, external := range []string{`src="http`, `href="http`, "<link "} {
+		if strings.Contains(out, external) {
+			t.Errorf("report references an external resource (%s)", external)
+		}
+	}
+}
@@ -3,61 +3,194 @@ package reporter
 import (
 	"encoding/json"
 	"io"
+	"time"
 
+	"github.com/pthm/cclint/internal/analyzer"
 	"github.com/pthm/cclint/internal/rules"
+	"github.com/pthm/cclint/internal/version"
 )
 
+// JSONSchemaVersion is the version of the JSON output format. It changes
+// whenever a field is renamed or removed; added fields keep the version.
+const JSONSchemaVersion = 2
+
 // JSONReporter outputs results as JSON
 type JSONReporter struct {
-	w io.Writer
+	w    io.Writer
+	data ReportData
 }
 
-// NewJSONReporter creates a new JSON reporter
-func NewJSONReporter(w io.Writer) *JSONReporter {
-	return &JSONReporter{w: w}
+// NewJSONReporter creates a new JSON reporter. data provides the scopes,
+// rule metadata and timings; any of it may be empty.
+func NewJSONReporter(w io.Writer, data ReportData) *JSONReporter {
+	return &JSONReporter{w: w, data: data}
 }
 
 // JSONOutput represents the JSON output format
 type JSONOutput struct {
-	Issues  []JSONIssue `json:"issues"`
-	Summary Summary     `json:"summary"`
+	SchemaVersion int              `json:"schemaVersion"`
+	Tool          JSONTool         `json:"tool"`
+	Root          string           `json:"root,omitempty"`
+	Issues        []JSONIssue      `json:"issues"`
+	Summary       Summary          `json:"summary"`
+	Rules         []JSONRule       `json:"rules"`
+	FailedRules   []JSONFailedRule `json:"failedRules"`
+	Timings       JSONTimings      `json:"timings"`
+}
+
+// JSONTool identifies the tool that produced the output
+type JSONTool struct {
+	Name    string `json:"name"`
+	Version string `json:"version"`
 }
 
 // JSONIssue represents an issue in JSON format
 type JSONIssue struct {
-	Rule     string `json:"rule"`
-	Severity string `json:"severity"`
-	Message  string `json:"message"`
-	File     string `json:"file"`
-	Line     int    `json:"line,omitempty"`
-	Column   int    `json:"column,omitempty"`
-	EndLine  int    `json:"endLine,omitempty"`
-	Context  string `json:"context,omitempty"`
-	HasFix   bool   `json:"hasFix"`
+	Rule      string      `json:"rule"`
+	Severity  string      `json:"severity"`
+	Message   string      `json:"message"`
+	File      string      `json:"file"`
+	Line      int         `json:"line,omitempty"`
+	Column    int         `json:"column,omitempty"`
+	EndColumn int         `json:"endColumn,omitempty"`
+	EndLine   int         `json:"endLine,omitempty"`
+	Context   string      `json:"context,omitempty"`
+	HasFix    bool        `json:"hasFix"`
+	Fix       *JSONFix    `json:"fix,omitempty"`
+	Scopes    []JSONScope `json:"scopes"`
+}
+
+// JSONFix is an issue's automatic fix
+type JSONFix struct {
+	Description string     `json:"description"`
+	Edits       []JSONEdit `json:"edits"`
+}
+
+// JSONEdit is one edit of a fix. Without columns it replaces whole lines;
+// columns are 1-based byte offsets with an exclusive end.
+type JSONEdit struct {
+	File        string `json:"file"`
+	StartLine   int    `json:"startLine"`
+	EndLine     int    `json:"endLine"`
+	StartColumn int    `json:"startColumn,omitempty"`
+	EndColumn   int    `json:"endColumn,omitempty"`
+	NewContent  string `json:"newContent"`
+}
+
+// JSONScope identifies a context scope an issue's file belongs to
+type JSONScope struct {
+	Type string `json:"type"`
+	Name string `json:"name"`
+}
+
+// JSONRule describes a rule that ran
+type JSONRule struct {
+	Name        string  `json:"name"`
+	Description string  `json:"description"`
+	Category    string  `json:"category,omitempty"`
+	RequiresAI  bool    `json:"requiresAI"`
+	DurationMS  float64 `json:"durationMs"`
+	Failed      bool    `json:"failed"`
+}
+
+// JSONFailedRule is a rule that failed to run
+type JSONFailedRule struct {
+	Name  string `json:"name"`
+	Error string `json:"error"`
+}
+
+// JSONTimings are how long the run took, in milliseconds
+type JSONTimings struct {
+	TotalMS float64 `json:"totalMs"`
+	RulesMS float64 `json:"rulesMs"`
 }
 
 // Report outputs issues as JSON
 func (r *JSONReporter) Report(issues []rules.Issue) error {
 	output := JSONOutput{
-		Issues:  make([]JSONIssue, 0, len(issues)),
-		Summary: ComputeSummary(issues),
+		SchemaVersion: JSONSchemaVersion,
+		Tool:          JSONTool{Name: "cclint", Version: version.Short()},
+		Root:          r.data.RootPath,
+		Issues:        make([]JSONIssue, 0, len(issues)),
+		Summary:       ComputeSummary(issues),
+		Rules:         make([]JSONRule, 0, len(r.data.Rules)),
+		FailedRules:   []JSONFailedRule{},
+		Timings:       JSONTimings{TotalMS: milliseconds(r.data.Duration)},
 	}
 
+	scopes := scopesByFile(r.data.Scopes)
 	for _, issue := range issues {
-		output.Issues = append(output.Issues, JSONIssue{
-			Rule:     issue.Rule,
-			Severity: issue.Severity.String(),
-			Message:  issue.Message,
-			File:     issue.File,
-			Line:     issue.Line,
-			Column:   issue.Column,
-			EndLine:  issue.EndLine,
-			Context:  issue.Context,
-			HasFix:   issue.Fix != nil,
+		jsonIssue := JSONIssue{
+			Rule:      issue.Rule,
+			Severity:  issue.Severity.String(),
+			Message:   issue.Message,
+			File:      issue.File,
+			Line:      issue.Line,
+			Column:    issue.Column,
+			EndColumn: issue.EndColumn,
+			EndLine:   issue.EndLine,
+			Context:   issue.Context,
+			HasFix:    issue.Fix != nil,
+			Scopes:    scopes[issue.File],
+		}
+		if jsonIssue.Scopes == nil {
+			jsonIssue.Scopes = []JSONScope{}
+		}
+		if issue.Fix != nil {
+			jsonIssue.Fix = &JSONFix{Description: issue.Fix.Description, Edits: make([]JSONEdit, 0, len(issue.Fix.Edits))}
+			for _, edit := range issue.Fix.Edits {
+				jsonIssue.Fix.Edits = append(jsonIssue.Fix.Edits, JSONEdit{
+					File:        edit.File,
+					StartLine:   edit.StartLine,
+					EndLine:     max(edit.EndLine, edit.StartLine),
+					StartColumn: edit.StartColumn,
+					EndColumn:   edit.EndColumn,
+					NewContent:  edit.NewContent,
+				})
+			}
+		}
+		output.Issues = append(output.Issues, jsonIssue)
+	}
+
+	for _, run := range r.data.Rules {
+		output.Rules = append(output.Rules, JSONRule{
+			Name:        run.Rule.Name(),
+			Description: run.Rule.Description(),
+			Category:    string(run.Category),
+			RequiresAI:  run.Rule.Config().RequiresAI,
+			DurationMS:  milliseconds(run.Duration),
+			Failed:      run.Err != nil,
 		})
+		output.Timings.RulesMS += milliseconds(run.Duration)
+		if run.Err != nil {
+			output.FailedRules = append(output.FailedRules, JSONFailedRule{Name: run.Rule.Name(), Error: run.Err.Error()})
+		}
 	}
 
 	encoder := json.NewEncoder(r.w)
 	encoder.SetIndent("", "  ")
 	return encoder.Encode(output)
 }
+
+// scopesByFile maps each file to the scopes that include it, in discovery
+// order
+func scopesByFile(scopes []*analyzer.ContextScope) map[string][]JSONScope {
+	byFile := make(map[string][]JSONScope)
+	for _, scope := range rules.FlattenScopes(scopes) {
+		ref := JSONScope{Type: scope.Type.String(), Name: scope.Name}
+		seen := make(map[string]bool)
+		for _, node := range scope.Nodes {
+			if seen[node.Path] {
+				continue
+			}
+			seen[node.Path] = true
+			byFile[node.Path] = append(byFile[node.Path], ref)
+		}
+	}
+	return byFile
+}
+
+// milliseconds converts a duration to fractional milliseconds
+func milliseconds(d time.Duration) float64 {
+	return float64(d.Microseconds()) / 1000
+}
@@ -0,0 +1,104 @@
+package reporter
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/pthm/cclint/internal/rules"
+)
+
+func TestJSONReporter(t *testing.T) {
+	root := filepath.FromSlash("/work/repo")
+	claudeMD := filepath.Join(root, "CLAUDE.md")
+	data := testReportData(root)
+	data.Rules = []RuleRun{
+		{Rule: stubRule{"broken-refs", "Checks references"}, Category: rules.CategoryStructural, Duration: 1500 * time.Microsecond},
+		{Rule: stubRule{"secrets", "Checks for secrets"}, Category: rules.CategorySecurity, Duration: time.Millisecond, Err: errors.New("boom")},
+	}
+	data.Duration = 10 * time.Millisecond
+
+	issues := []rules.Issue{
+		{
+			Rule: "broken-refs", Severity: rules.Error, Message: "Missing", File: claudeMD, Line: 2, Column: 7, EndColumn: 19,
+			Fix: &rules.Fix{Description: "Change reference", Edits: []rules.Edit{
+				rules.ReplaceText(claudeMD, 2, 7, 19, "docs/guide.md"),
+				{File: claudeMD, StartLine: 4, NewContent: "new"},
+			}},
+		},
+		{Rule: "verbosity", Severity: rules.Info, Message: "Long", File: filepath.Join(root, "AGENTS.md")},
+	}
+
+	var buf bytes.Buffer
+	if err := NewJSONReporter(&buf, data).Report(issues); err != nil {
+		t.Fatal(err)
+	}
+
+	// Decode generically to check the key names
+	var raw map[string]any
+	if err := json.Unmarshal(buf.Bytes(), &raw); err != nil {
+		t.Fatal(err)
+	}
+	if summary, ok := raw["summary"].(map[string]any); !ok || summary["totalIssues"] != float64(2) {
+		t.Errorf("summary = %v", raw["summary"])
+	}
+	if issues, ok := raw["issues"].([]any); !ok || issues[0].(map[string]any)["endColumn"] != float64(19) {
+		t.Errorf("issues = %v, want endColumn 19 on the first", raw["issues"])
+	}
+
+	var output JSONOutput
+	if err := json.Unmarshal(buf.Bytes(), &output); err != nil {
+		t.Fatal(err)
+	}
+	if output.SchemaVersion != JSONSchemaVersion || output.Tool.Name != "cclint" || output.Tool.Version == "" {
+		t.Errorf("header = %d %+v", output.SchemaVersion, output.Tool)
+	}
+
+	fix := output.Issues[0].Fix
+	if !output.Issues[0].HasFix || fix == nil || fix.Description != "Change reference" || len(fix.Edits) != 2 {
+		t.Fatalf("fix = %+v", fix)
+	}
+	if fix.Edits[0] != (JSONEdit{File: claudeMD, StartLine: 2, EndLine: 2, StartColumn: 7, EndColumn: 19, NewContent: "docs/guide.md"}) {
+		t.Errorf("range edit = %+v", fix.Edits[0])
+	}
+	if fix.Edits[1] != (JSONEdit{File: claudeMD, StartLine: 4, EndLine: 4, NewContent: "new"}) {
+		t.Errorf("line edit = %+v", fix.Edits[1])
+	}
+
+	if scopes := output.Issues[0].Scopes; len(scopes) != 1 || scopes[0] != (JSONScope{Type: "main", Name: "main"}) {
+		t.Errorf("CLAUDE.md scopes = %+v", scopes)
+	}
+	if scopes := output.Issues[1].Scopes; scopes == nil || len(scopes) != 0 {
+		t.Errorf("file outside any scope has scopes %+v", scopes)
+	}
+
+	if len(output.Rules) != 2 || output.Rules[0] != (JSONRule{Name: "broken-refs", Description: "Checks references", Category: "structural", DurationMS: 1.5}) {
+		t.Errorf("rules = %+v", output.Rules)
+	}
+	if !output.Rules[1].Failed || len(output.FailedRules) != 1 || output.FailedRules[0] != (JSONFailedRule{Name: "secrets", Error: "boom"}) {
+		t.Errorf("failed rules = %+v", output.FailedRules)
+	}
+	if output.Timings != (JSONTimings{TotalMS: 10, RulesMS: 2.5}) {
+		t.Errorf("timings = %+v", output.Timings)
+	}
+}
+
+func TestJSONReporterEmpty(t *testing.T) {
+	var buf bytes.Buffer
+	if err := NewJSONReporter(&buf, ReportData{}).Report(nil); err != nil {
+		t.Fatal(err)
+	}
+	var raw map[string]any
+	if err := json.Unmarshal(buf.Bytes(), &raw); err != nil {
+		t.Fatal(err)
+	}
+	// Lists are empty arrays, never null
+	for _, key := range []string{"issues", "rules", "failedRules"} {
+		if _, ok := raw[key].([]any); !ok {
+			t.Errorf("%s = %v, want an array", key, raw[key])
+		}
+	}
+}
@@ -0,0 +1,118 @@
+package reporter
+
+import (
+	"encoding/xml"
+	"fmt"
+	"io"
+
+	"github.com/pthm/cclint/internal/rules"
+)
+
+// JUnitReporter outputs results as JUnit XML, so CI test report views list
+// each issue. Errors and warnings are failures; suggestions and info are
+// passing test cases that carry their message as output.
+type JUnitReporter struct {
+	w       io.Writer
+	baseDir string
+}
+
+// NewJUnitReporter creates a new JUnit reporter. File paths are made
+// relative to baseDir.
+func NewJUnitReporter(w io.Writer, baseDir string) *JUnitReporter {
+	return &JUnitReporter{w: w, baseDir: baseDir}
+}
+
+// JUnitTestSuites is the JUnit root element
+type JUnitTestSuites struct {
+	XMLName  xml.Name         `xml:"testsuites"`
+	Name     string           `xml:"name,attr"`
+	Tests    int              `xml:"tests,attr"`
+	Failures int              `xml:"failures,attr"`
+	Suites   []JUnitTestSuite `xml:"testsuite"`
+}
+
+// JUnitTestSuite holds the test cases for one file
+type JUnitTestSuite struct {
+	Name     string          `xml:"name,attr"`
+	Tests    int             `xml:"tests,attr"`
+	Failures int             `xml:"failures,attr"`
+	Errors   int             `xml:"errors,attr"`
+	Cases    []JUnitTestCase `xml:"testcase"`
+}
+
+// JUnitTestCase is a single issue
+type JUnitTestCase struct {
+	Name      string        `xml:"name,attr"`
+	ClassName string        `xml:"classname,attr"`
+	File      string        `xml:"file,attr,omitempty"`
+	Line      int           `xml:"line,attr,omitempty"`
+	Failure   *JUnitFailure `xml:"failure,omitempty"`
+	SystemOut string        `xml:"system-out,omitempty"`
+}
+
+// JUnitFailure marks a test case as failed
+type JUnitFailure struct {
+	Message string `xml:"message,attr"`
+	Type    string `xml:"type,attr"`
+	Text    string `xml:",chardata"`
+}
+
+// Report outputs one test suite per file and one test case per issue. A run
+// without issues reports a single passing test case.
+func (r *JUnitReporter) Report(issues []rules.Issue) error {
+	output := JUnitTestSuites{Name: "cclint"}
+
+	suiteIndex := make(map[string]int)
+	for _, issue := range issues {
+		file := relativePath(r.baseDir, issue.File)
+		i, ok := suiteIndex[file]
+		if !ok {
+			i = len(output.Suites)
+			suiteIndex[file] = i
+			output.Suites = append(output.Suites, JUnitTestSuite{Name: file})
+		}
+
+		location := file
+		if issue.Line > 0 {
+			location = fmt.Sprintf("%s:%d", file, issue.Line)
+		}
+		tc := JUnitTestCase{
+			Name:      fmt.Sprintf("%s %s", issue.Rule, location),
+			ClassName: "cclint." + issue.Rule,
+			File:      file,
+			Line:      issue.Line,
+		}
+		details := fmt.Sprintf("%s: %s\n%s", issue.Severity, issue.Message, location)
+		if issue.Severity == rules.Error || issue.Severity == rules.Warning {
+			tc.Failure = &JUnitFailure{Message: issue.Message, Type: issue.Severity.String(), Text: details}
+			output.Suites[i].Failures++
+			output.Failures++
+		} else {
+			tc.SystemOut = details
+		}
+
+		output.Suites[i].Cases = append(output.Suites[i].Cases, tc)
+		output.Suites[i].Tests++
+		output.Tests++
+	}
+
+	if len(output.Suites) == 0 {
+		output.Suites = []JUnitTestSuite{{
+			Name:  "cclint",
+			Tests: 1,
+			Cases: []JUnitTestCase{{Name: "no issues", ClassName: "cclint"}},
+		}}
+		output.Tests = 1
+	}
+
+	if _, err := io.WriteString(r.w, xml.Header); err != nil {
+		return err
+	}
+	encoder := xml.NewEncoder(r.w)
+	encoder.Indent("", "  ")
+	if err := encoder.Encode(output); err != nil {
+		return err
+	}
+	_, err := io.WriteString(r.w, "\n")
+	return err
+}
@@ -0,0 +1,63 @@
+package reporter
+
+import (
+	"bytes"
+	"encoding/xml"
+	"path/filepath"
+	"testing"
+
+	"github.com/pthm/cclint/internal/rules"
+)
+
+func TestJUnitReporter(t *testing.T) {
+	base := filepath.FromSlash("/work/repo")
+	issues := []rules.Issue{
+		{Rule: "broken-refs", Severity: rules.Error, Message: "Missing", File: filepath.Join(base, "CLAUDE.md"), Line: 2},
+		{Rule: "vague-instructions", Severity: rules.Suggestion, Message: "Vague", File: filepath.Join(base, "CLAUDE.md"), Line: 4},
+		{Rule: "secrets", Severity: rules.Warning, Message: "Key", File: filepath.Join(base, "AGENTS.md")},
+	}
+
+	var buf bytes.Buffer
+	if err := NewJUnitReporter(&buf, base).Report(issues); err != nil {
+		t.Fatal(err)
+	}
+
+	var output JUnitTestSuites
+	if err := xml.Unmarshal(buf.Bytes(), &output); err != nil {
+		t.Fatalf("invalid XML: %v\n%s", err, buf.String())
+	}
+	if output.Tests != 3 || output.Failures != 2 || len(output.Suites) != 2 {
+		t.Fatalf("tests %d, failures %d, suites %d", output.Tests, output.Failures, len(output.Suites))
+	}
+
+	suite := output.Suites[0]
+	if suite.Name != "CLAUDE.md" || suite.Tests != 2 || suite.Failures != 1 {
+		t.Errorf("suite = %+v", suite)
+	}
+	failed := suite.Cases[0]
+	if failed.Name != "broken-refs CLAUDE.md:2" || failed.Failure == nil || failed.Failure.Type != "error" || failed.Failure.Message != "Missing" {
+		t.Errorf("failed case = %+v", failed)
+	}
+	// Suggestions pass, carrying their message as output
+	passed := suite.Cases[1]
+	if passed.Failure != nil || passed.SystemOut != "suggestion: Vague\nCLAUDE.md:4" {
+		t.Errorf("suggestion case = %+v", passed)
+	}
+	if output.Suites[1].Cases[0].Name != "secrets AGENTS.md" {
+		t.Errorf("file-level case name = %s", output.Suites[1].Cases[0].Name)
+	}
+}
+
+func TestJUnitReporterNoIssues(t *testing.T) {
+	var buf bytes.Buffer
+	if err := NewJUnitReporter(&buf, "").Report(nil); err != nil {
+		t.Fatal(err)
+	}
+	var output JUnitTestSuites
+	if err := xml.Unmarshal(buf.Bytes(), &output); err != nil {
+		t.Fatal(err)
+	}
+	if output.Tests != 1 || output.Failures != 0 || len(output.Suites) != 1 || len(output.Suites[0].Cases) != 1 {
+		t.Errorf("output = %+v", output)
+	}
+}
@@ -0,0 +1,173 @@
+package reporter
+
+import (
+	"fmt"
+	"io"
+	"strings"
+
+	"github.com/pthm/cclint/internal/rules"
+)
+
+// MarkdownReporter outputs results as GitHub-flavored markdown, suitable for
+// posting as a pull request comment
+type MarkdownReporter struct {
+	w    io.Writer
+	data ReportData
+}
+
+// NewMarkdownReporter creates a new markdown reporter
+func NewMarkdownReporter(w io.Writer, data ReportData) *MarkdownReporter {
+	return &MarkdownReporter{w: w, data: data}
+}
+
+// severityIcons mark severities in markdown and HTML reports
+var severityIcons = map[rules.Severity]string{
+	rules.Error:      "🔴",
+	rules.Warning:    "🟠",
+	rules.Suggestion: "🔵",
+	rules.Info:       "⚪",
+}
+
+// Report outputs a summary table, scope token budgets and a collapsible
+// section of issues per file
+func (r *MarkdownReporter) Report(issues []rules.Issue) error {
+	var sb strings.Builder
+	s := ComputeSummary(issues)
+
+	sb.WriteString("## cclint report\n\n")
+	if s.TotalIssues == 0 {
+		sb.WriteString("✅ No issues found\n\n")
+	} else {
+		fmt.Fprintf(&sb, "Found **%s** in %s.\n\n", plural(s.TotalIssues, "issue"), plural(s.Files, "file"))
+		sb.WriteString("| Severity | Count |\n|---|---:|\n")
+		for _, row := range []struct {
+			severity rules.Severity
+			count    int
+		}{
+			{rules.Error, s.Errors},
+			{rules.Warning, s.Warnings},
+			{rules.Suggestion, s.Suggestions},
+			{rules.Info, s.Info},
+		} {
+			fmt.Fprintf(&sb, "| %s %s | %d |\n", severityIcons[row.severity], row.severity, row.count)
+		}
+		sb.WriteString("\n")
+	}
+
+	r.writeBudgets(&sb)
+
+	files, byFile := issuesByFile(issues)
+	if len(files) > 0 {
+		sb.WriteString("### Issues\n\n")
+	}
+	for _, file := range files {
+		fileIssues := byFile[file]
+		fileSummary := ComputeSummary(fileIssues)
+
+		// Files with errors start expanded
+		open := ""
+		if fileSummary.Errors > 0 {
+			open = " open"
+		}
+		fmt.Fprintf(&sb, "<details%s>\n<summary><code>%s</code> — %s</summary>\n\n",
+			open, escapeMarkdownHTML(relativePath(r.data.RootPath, file)), countLabel(fileSummary))
+
+		sb.WriteString("| Line | Severity | Rule | Message |\n|---:|---|---|---|\n")
+		for _, issue := range fileIssues {
+			fmt.Fprintf(&sb, "| %s | %s %s | `%s` | %s |\n",
+				issuePosition(issue), severityIcons[issue.Severity], issue.Severity, issue.Rule, escapeMarkdownCell(issue.Message))
+		}
+		sb.WriteString("\n</details>\n\n")
+	}
+
+	_, err := io.WriteString(r.w, sb.String())
+	return err
+}
+
+// writeBudgets writes the table of scope token use against budgets
+func (r *MarkdownReporter) writeBudgets(sb *strings.Builder) {
+	budgets := r.data.scopeBudgets()
+	if len(budgets) == 0 {
+		return
+	}
+
+	sb.WriteString("### Context budgets\n\n")
+	sb.WriteString("| Scope | Type | Files | Tokens | Budget | |\n|---|---|---:|---:|---:|---|\n")
+	for _, b := range budgets {
+		name := escapeMarkdownCell(b.Scope.Name)
+		if b.Depth > 0 {
+			name = strings.Repeat("&nbsp;&nbsp;", b.Depth-1) + "↳ " + name
+		}
+
+		budget, status := "—", ""
+		if b.Budget > 0 {
+			budget = formatCount(b.Budget)
+			status = fmt.Sprintf("✅ %d%%", b.Percent())
+			if b.Over() {
+				status = fmt.Sprintf("⚠️ %d%%", b.Percent())
+			}
+		}
+		fmt.Fprintf(sb, "| %s | %s | %d | %s | %s | %s |\n",
+			name, b.Scope.Type, len(b.Scope.Nodes), formatCount(b.Tokens), budget, status)
+	}
+	sb.WriteString("\nToken counts are estimates (~4 characters per token).\n\n")
+}
+
+// issuePosition formats an issue's line and column
+func issuePosition(issue rules.Issue) string {
+	switch {
+	case issue.Line == 0:
+		return "—"
+	case issue.Column > 0:
+		return fmt.Sprintf("%d:%d", issue.Line, issue.Column)
+	default:
+		return fmt.Sprintf("%d", issue.Line)
+	}
+}
+
+// countLabel describes a file's issue counts, e.g. "3 issues (1 error)"
+func countLabel(s Summary) string {
+	label := plural(s.TotalIssues, "issue")
+	var parts []string
+	if s.Errors > 0 {
+		parts = append(parts, plural(s.Errors, "error"))
+	}
+	if s.Warnings > 0 {
+		parts = append(parts, plural(s.Warnings, "warning"))
+	}
+	if len(parts) > 0 {
+		label += " (" + strings.Join(parts, ", ") + ")"
+	}
+	return label
+}
+
+// plural formats a count with a singular or plural noun
+func plural(n int, noun string) string {
+	if n == 1 {
+		return fmt.Sprintf("1 %s", noun)
+	}
+	return fmt.Sprintf("%d %ss", n, noun)
+}
+
+// formatCount formats a number with thousands separators
+func formatCount(n int) string {
+	s := fmt.Sprintf("%d", n)
+	for i := len(s) - 3; i > 0 && s[i-1] != '-'; i -= 3 {
+		s = s[:i] + "," + s[i:]
+	}
+	return s
+}
+
+// escapeMarkdownCell makes text safe inside a markdown table cell
+func escapeMarkdownCell(s string) string {
+	s = escapeMarkdownHTML(s)
+	s = strings.ReplaceAll(s, "|", "\\|")
+	return strings.Join(strings.Fields(s), " ")
+}
+
+// escapeMarkdownHTML escapes characters markdown would treat as HTML
+func escapeMarkdownHTML(s string) string {
+	s = strings.ReplaceAll(s, "&", "&amp;")
+	s = strings.ReplaceAll(s, "<", "&lt;")
+	return strings.ReplaceAll(s, ">", "&gt;")
+}
@@ -0,0 +1,89 @@
+package reporter
+
+import (
+	"bytes"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/pthm/cclint/internal/agent"
+	"github.com/pthm/cclint/internal/analyzer"
+	"github.com/pthm/cclint/internal/rules"
+)
+
+// testReportData returns a main scope within budget and a command over it
+func testReportData(root string) ReportData {
+	command := &analyzer.ContextScope{
+		Type:       analyzer.ScopeTypeCommand,
+		Name:       "deploy",
+		Entrypoint: filepath.Join(root, ".claude", "commands", "deploy.md"),
+		Nodes: []*analyzer.ConfigNode{
+			{Path: filepath.Join(root, ".claude", "commands", "deploy.md"), Content: bytes.Repeat([]byte("x"), 400)},
+		},
+	}
+	main := &analyzer.ContextScope{
+		Type:     analyzer.ScopeTypeMain,
+		Name:     "main",
+		Nodes:    []*analyzer.ConfigNode{{Path: filepath.Join(root, "CLAUDE.md"), Content: bytes.Repeat([]byte("x"), 4000)}},
+		Children: []*analyzer.ContextScope{command},
+	}
+	return ReportData{
+		RootPath:    root,
+		AgentConfig: &agent.Config{TokenBudgets: map[string]int{"main": 2000, "command": 50}},
+		Scopes:      []*analyzer.ContextScope{main},
+	}
+}
+
+func TestMarkdownReporter(t *testing.T) {
+	root := filepath.FromSlash("/work/repo")
+	issues := []rules.Issue{
+		{Rule: "vague-instructions", Severity: rules.Suggestion, Message: "Use a | b <tag>", File: filepath.Join(root, "CLAUDE.md"), Line: 9},
+		{Rule: "broken-refs", Severity: rules.Error, Message: "Missing", File: filepath.Join(root, "CLAUDE.md"), Line: 2, Column: 7},
+		{Rule: "verbosity", Severity: rules.Info, Message: "Long", File: filepath.Join(root, "AGENTS.md")},
+	}
+
+	var buf bytes.Buffer
+	if err := NewMarkdownReporter(&buf, testReportData(root)).Report(issues); err != nil {
+		t.Fatal(err)
+	}
+	out := buf.String()
+
+	for _, want := range []string{
+		"Found **3 issues** in 2 files.",
+		"| 🔴 error | 1 |\n| 🟠 warning | 0 |\n| 🔵 suggestion | 1 |\n| ⚪ info | 1 |",
+		"| main | main | 1 | 1,000 | 2,000 | ✅ 50% |",
+		"| ↳ deploy | command | 1 | 100 | 50 | ⚠️ 200% |",
+		// Files are sorted; only files with errors start expanded
+		"<details>\n<summary><code>AGENTS.md</code> — 1 issue</summary>",
+		"<details open>\n<summary><code>CLAUDE.md</code> — 2 issues (1 error)</summary>",
+		"| — | ⚪ info | `verbosity` | Long |",
+		// Issues are sorted by line, and table cells are escaped
+		"| 2:7 | 🔴 error | `broken-refs` | Missing |\n| 9 | 🔵 suggestion | `vague-instructions` | Use a \\| b &lt;tag&gt; |",
+	} {
+		if !strings.Contains(out, want) {
+			t.Errorf("output missing %q:\n%s", want, out)
+		}
+	}
+	if strings.Index(out, "AGENTS.md") > strings.Index(out, "<code>CLAUDE.md") {
+		t.Error("files are not sorted")
+	}
+}
+
+func TestMarkdownReporterNoIssues(t *testing.T) {
+	var buf bytes.Buffer
+	if err := NewMarkdownReporter(&buf, ReportData{}).Report(nil); err != nil {
+		t.Fatal(err)
+	}
+	if out := buf.String(); !strings.Contains(out, "No issues found") || strings.Contains(out, "Context budgets") {
+		t.Errorf("output = %q", out)
+	}
+}
+
+func TestFormatCount(t *testing.T) {
+	tests := map[int]string{0: "0", 999: "999", 1000: "1,000", 1234567: "1,234,567", -1000: "-1,000"}
+	for n, want := range tests {
+		if got := formatCount(n); got != want {
+			t.Errorf("formatCount(%d) = %q, want %q", n, got, want)
+		}
+	}
+}
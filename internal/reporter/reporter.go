@@ -1,6 +1,13 @@
 package reporter
 
 import (
+	"path/filepath"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/pthm/cclint/internal/agent"
+	"github.com/pthm/cclint/internal/analyzer"
 	"github.com/pthm/cclint/internal/rules"
 )
 
@@ -10,14 +17,97 @@ type Reporter interface {
 	Report(issues []rules.Issue) error
 }
 
+// ReportData is the project context document reporters (markdown, HTML)
+// show alongside the issues
+type ReportData struct {
+	RootPath    string
+	AgentConfig *agent.Config
+	Scopes      []*analyzer.ContextScope
+
+	// Rules are the rules that ran, with their timings and errors
+	Rules []RuleRun
+
+	// Duration is how long the whole run took
+	Duration time.Duration
+}
+
+// RuleRun records one rule's run
+type RuleRun struct {
+	Rule     rules.Rule
+	Category rules.Category
+	Duration time.Duration
+	Err      error // Set if the rule failed to run
+}
+
+// scopeBudget is a scope's token use against its budget
+type scopeBudget struct {
+	Scope  *analyzer.ContextScope
+	Depth  int // Nesting level in the scope tree
+	Tokens int
+	Budget int // 0 if the scope type has no budget
+}
+
+// Percent returns the share of the budget used
+func (b scopeBudget) Percent() int {
+	if b.Budget == 0 {
+		return 0
+	}
+	return b.Tokens * 100 / b.Budget
+}
+
+// Over reports whether the scope exceeds its budget
+func (b scopeBudget) Over() bool {
+	return b.Budget > 0 && b.Tokens > b.Budget
+}
+
+// scopeBudgets lists every scope, depth first, with its token use
+func (d ReportData) scopeBudgets() []scopeBudget {
+	var budgets []scopeBudget
+	var walk func(scopes []*analyzer.ContextScope, depth int)
+	walk = func(scopes []*analyzer.ContextScope, depth int) {
+		for _, scope := range scopes {
+			b := scopeBudget{Scope: scope, Depth: depth, Tokens: scope.EstimatedTokens()}
+			if d.AgentConfig != nil {
+				b.Budget = d.AgentConfig.TokenBudget(scope.Type.String())
+			}
+			budgets = append(budgets, b)
+			walk(scope.Children, depth+1)
+		}
+	}
+	walk(d.Scopes, 0)
+	return budgets
+}
+
+// issuesByFile groups issues by file, with files sorted by path and issues
+// by position
+func issuesByFile(issues []rules.Issue) ([]string, map[string][]rules.Issue) {
+	byFile := make(map[string][]rules.Issue)
+	for _, issue := range issues {
+		byFile[issue.File] = append(byFile[issue.File], issue)
+	}
+
+	files := make([]string, 0, len(byFile))
+	for file, fileIssues := range byFile {
+		files = append(files, file)
+		sort.SliceStable(fileIssues, func(i, j int) bool {
+			if fileIssues[i].Line != fileIssues[j].Line {
+				return fileIssues[i].Line < fileIssues[j].Line
+			}
+			return fileIssues[i].Column < fileIssues[j].Column
+		})
+	}
+	sort.Strings(files)
+	return files, byFile
+}
+
 // Summary holds summary statistics for a lint run
 type Summary struct {
-	TotalIssues int
-	Errors      int
-	Warnings    int
-	Suggestions int
-	Info        int
-	Files       int
+	TotalIssues int `json:"totalIssues"`
+	Errors      int `json:"errors"`
+	Warnings    int `json:"warnings"`
+	Suggestions int `json:"suggestions"`
+	Info        int `json:"info"`
+	Files       int `json:"files"`
 }
 
 // ComputeSummary computes summary statistics from issues
@@ -44,3 +134,26 @@ func ComputeSummary(issues []rules.Issue) Summary {
 
 	return s
 }
+
+// relativePath returns path relative to base with forward slashes, or the
+// path unchanged if it is outside base
+func relativePath(base, path string) string {
+	if base == "" {
+		return path
+	}
+	rel, err := filepath.Rel(base, path)
+	if err != nil || rel == ".." || strings.HasPrefix(rel, ".."+string(filepath.Separator)) {
+		return path
+	}
+	return filepath.ToSlash(rel)
+}
+
+// hasErrors reports whether any issue is an error
+func hasErrors(issues []rules.Issue) bool {
+	for _, issue := range issues {
+		if issue.Severity == rules.Error {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,383 @@
+package reporter
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/url"
+	"os"
+	"path/filepath"
+	"strings"
+	"unicode/utf8"
+
+	"github.com/pthm/cclint/internal/rules"
+	"github.com/pthm/cclint/internal/version"
+)
+
+const (
+	sarifVersion = "2.1.0"
+	sarifSchema  = "https://json.schemastore.org/sarif-2.1.0.json"
+
+	// sarifRootBase is the uriBaseId for paths relative to the project root
+	sarifRootBase = "%SRCROOT%"
+
+	// fingerprintKey names cclint's partial fingerprint; bump the version if
+	// the way it is computed changes
+	fingerprintKey = "cclintIssueHash/v1"
+)
+
+// SARIFReporter outputs results as SARIF 2.1.0 for code scanning tools
+type SARIFReporter struct {
+	w        io.Writer
+	rootPath string
+	rules    []rules.Rule
+
+	lines map[string][]string // File lines, read on demand
+}
+
+// NewSARIFReporter creates a new SARIF reporter. Paths are made relative to
+// rootPath, and ruleList provides the rule metadata.
+func NewSARIFReporter(w io.Writer, rootPath string, ruleList []rules.Rule) *SARIFReporter {
+	return &SARIFReporter{
+		w:        w,
+		rootPath: rootPath,
+		rules:    ruleList,
+		lines:    make(map[string][]string),
+	}
+}
+
+// SARIFLog is the top-level SARIF document
+type SARIFLog struct {
+	Version string     `json:"version"`
+	Schema  string     `json:"$schema"`
+	Runs    []SARIFRun `json:"runs"`
+}
+
+// SARIFRun is a single run of cclint
+type SARIFRun struct {
+	Tool               SARIFTool                        `json:"tool"`
+	OriginalURIBaseIDs map[string]SARIFArtifactLocation `json:"originalUriBaseIds,omitempty"`
+	ColumnKind         string                           `json:"columnKind"`
+	Results            []SARIFResult                    `json:"results"`
+}
+
+// SARIFTool describes cclint
+type SARIFTool struct {
+	Driver SARIFDriver `json:"driver"`
+}
+
+// SARIFDriver describes cclint and its rules
+type SARIFDriver struct {
+	Name           string                `json:"name"`
+	Version        string                `json:"version,omitempty"`
+	InformationURI string                `json:"informationUri"`
+	Rules          []SARIFRuleDescriptor `json:"rules"`
+}
+
+// SARIFRuleDescriptor is the metadata for a rule
+type SARIFRuleDescriptor struct {
+	ID               string         `json:"id"`
+	Name             string         `json:"name"`
+	ShortDescription SARIFMessage   `json:"shortDescription"`
+	Help             *SARIFMessage  `json:"help,omitempty"`
+	Properties       map[string]any `json:"properties,omitempty"`
+}
+
+// SARIFMessage is a plain text message
+type SARIFMessage struct {
+	Text     string `json:"text"`
+	Markdown string `json:"markdown,omitempty"`
+}
+
+// SARIFResult is a single issue
+type SARIFResult struct {
+	RuleID              string            `json:"ruleId"`
+	RuleIndex           int               `json:"ruleIndex"`
+	Level               string            `json:"level"`
+	Message             SARIFMessage      `json:"message"`
+	Locations           []SARIFLocation   `json:"locations"`
+	PartialFingerprints map[string]string `json:"partialFingerprints"`
+	Fixes               []SARIFFix        `json:"fixes,omitempty"`
+	Properties          map[string]any    `json:"properties,omitempty"`
+}
+
+// SARIFLocation is where an issue was found
+type SARIFLocation struct {
+	PhysicalLocation SARIFPhysicalLocation `json:"physicalLocation"`
+}
+
+// SARIFPhysicalLocation is a region of a file
+type SARIFPhysicalLocation struct {
+	ArtifactLocation SARIFArtifactLocation `json:"artifactLocation"`
+	Region           *SARIFRegion          `json:"region,omitempty"`
+}
+
+// SARIFArtifactLocation identifies a file
+type SARIFArtifactLocation struct {
+	URI       string `json:"uri"`
+	URIBaseID string `json:"uriBaseId,omitempty"`
+}
+
+// SARIFRegion is a range of lines and columns. Columns count Unicode code
+// points, as declared by the run's columnKind.
+type SARIFRegion struct {
+	StartLine   int `json:"startLine"`
+	StartColumn int `json:"startColumn,omitempty"`
+	EndLine     int `json:"endLine,omitempty"`
+	EndColumn   int `json:"endColumn,omitempty"`
+}
+
+// SARIFFix is a proposed fix
+type SARIFFix struct {
+	Description     SARIFMessage          `json:"description"`
+	ArtifactChanges []SARIFArtifactChange `json:"artifactChanges"`
+}
+
+// SARIFArtifactChange is the set of replacements in one file
+type SARIFArtifactChange struct {
+	ArtifactLocation SARIFArtifactLocation `json:"artifactLocation"`
+	Replacements     []SARIFReplacement    `json:"replacements"`
+}
+
+// SARIFReplacement replaces a region with new text
+type SARIFReplacement struct {
+	DeletedRegion   SARIFRegion   `json:"deletedRegion"`
+	InsertedContent *SARIFMessage `json:"insertedContent,omitempty"`
+}
+
+// Report outputs issues as a SARIF log
+func (r *SARIFReporter) Report(issues []rules.Issue) error {
+	driver := SARIFDriver{
+		Name:           "cclint",
+		Version:        version.Short(),
+		InformationURI: "https://github.com/pthm/cclint",
+		Rules:          []SARIFRuleDescriptor{},
+	}
+	ruleIndex := make(map[string]int)
+	for _, rule := range r.rules {
+		ruleIndex[rule.Name()] = len(driver.Rules)
+		driver.Rules = append(driver.Rules, ruleDescriptor(rule.Name(), rule.Description(), ""))
+	}
+
+	run := SARIFRun{
+		Tool: SARIFTool{Driver: driver},
+		OriginalURIBaseIDs: map[string]SARIFArtifactLocation{
+			sarifRootBase: {URI: fileURI(r.rootPath) + "/"},
+		},
+		ColumnKind: "unicodeCodePoints",
+		Results:    make([]SARIFResult, 0, len(issues)),
+	}
+
+	occurrences := make(map[string]int)
+	for _, issue := range issues {
+		index, ok := ruleIndex[issue.Rule]
+		if !ok {
+			// Sub-rules (rule/sub-rule) get their own descriptor, described by
+			// their parent rule
+			parent, _, _ := strings.Cut(issue.Rule, "/")
+			description := issue.Rule
+			if i, ok := ruleIndex[parent]; ok {
+				description = run.Tool.Driver.Rules[i].ShortDescription.Text
+			}
+			index = len(run.Tool.Driver.Rules)
+			ruleIndex[issue.Rule] = index
+			run.Tool.Driver.Rules = append(run.Tool.Driver.Rules, ruleDescriptor(issue.Rule, description, parent))
+		}
+
+		result := SARIFResult{
+			RuleID:    issue.Rule,
+			RuleIndex: index,
+			Level:     sarifLevel(issue.Severity),
+			Message:   SARIFMessage{Text: issue.Message},
+			Locations: []SARIFLocation{{PhysicalLocation: SARIFPhysicalLocation{
+				ArtifactLocation: r.artifactLocation(issue.File),
+				Region:           r.issueRegion(issue),
+			}}},
+			PartialFingerprints: map[string]string{
+				fingerprintKey: r.fingerprint(issue, occurrences),
+			},
+			Properties: map[string]any{"severity": issue.Severity.String()},
+		}
+		if fix := r.sarifFix(issue.Fix); fix != nil {
+			result.Fixes = []SARIFFix{*fix}
+		}
+		run.Results = append(run.Results, result)
+	}
+
+	encoder := json.NewEncoder(r.w)
+	encoder.SetIndent("", "  ")
+	return encoder.Encode(SARIFLog{
+		Version: sarifVersion,
+		Schema:  sarifSchema,
+		Runs:    []SARIFRun{run},
+	})
+}
+
+// ruleDescriptor builds the metadata for a rule; parent is set for sub-rules
+func ruleDescriptor(id, description, parent string) SARIFRuleDescriptor {
+	d := SARIFRuleDescriptor{
+		ID:               id,
+		Name:             id,
+		ShortDescription: SARIFMessage{Text: description},
+		Help: &SARIFMessage{
+			Text:     fmt.Sprintf("%s. Run `cclint lint` to check, or `cclint fix` to apply available fixes.", strings.TrimSuffix(description, ".")),
+			Markdown: fmt.Sprintf("**%s**: %s.\n\nRun `cclint lint` to check, or `cclint fix` to apply available fixes.", id, strings.TrimSuffix(description, ".")),
+		},
+	}
+	if parent != "" && parent != id {
+		d.Properties = map[string]any{"parent": parent}
+	}
+	return d
+}
+
+// sarifLevel maps a severity to a SARIF result level
+func sarifLevel(s rules.Severity) string {
+	switch s {
+	case rules.Error:
+		return "error"
+	case rules.Warning:
+		return "warning"
+	default:
+		return "note"
+	}
+}
+
+// artifactLocation returns a file's location, relative to the project root
+// when it is inside it
+func (r *SARIFReporter) artifactLocation(path string) SARIFArtifactLocation {
+	if rel, err := filepath.Rel(r.rootPath, path); err == nil && !strings.HasPrefix(rel, "..") {
+		return SARIFArtifactLocation{
+			URI:       (&url.URL{Path: filepath.ToSlash(rel)}).String(),
+			URIBaseID: sarifRootBase,
+		}
+	}
+	return SARIFArtifactLocation{URI: fileURI(path)}
+}
+
+// fileURI returns an absolute file:// URI for a path
+func fileURI(path string) string {
+	path = filepath.ToSlash(path)
+	if !strings.HasPrefix(path, "/") {
+		path = "/" + path // Windows drive letters
+	}
+	return (&url.URL{Scheme: "file", Path: path}).String()
+}
+
+// issueRegion returns the region an issue covers, or nil for file-level issues
+func (r *SARIFReporter) issueRegion(issue rules.Issue) *SARIFRegion {
+	if issue.Line < 1 {
+		return nil
+	}
+	region := &SARIFRegion{StartLine: issue.Line}
+	if issue.Column > 0 {
+		region.StartColumn = r.codePointColumn(issue.File, issue.Line, issue.Column)
+	}
+	if issue.EndLine > issue.Line {
+		region.EndLine = issue.EndLine
+	} else if issue.Column > 0 && issue.EndColumn > issue.Column {
+		// EndColumn is on Line, so it only bounds single-line regions
+		region.EndColumn = r.codePointColumn(issue.File, issue.Line, issue.EndColumn)
+	}
+	return region
+}
+
+// sarifFix converts a fix's edits to SARIF replacements, grouped per file
+func (r *SARIFReporter) sarifFix(fix *rules.Fix) *SARIFFix {
+	if fix == nil || len(fix.Edits) == 0 {
+		return nil
+	}
+
+	result := &SARIFFix{Description: SARIFMessage{Text: fix.Description}}
+	changes := make(map[string]int)
+	for _, edit := range fix.Edits {
+		i, ok := changes[edit.File]
+		if !ok {
+			i = len(result.ArtifactChanges)
+			changes[edit.File] = i
+			result.ArtifactChanges = append(result.ArtifactChanges, SARIFArtifactChange{
+				ArtifactLocation: r.artifactLocation(edit.File),
+			})
+		}
+
+		replacement := SARIFReplacement{DeletedRegion: r.editRegion(edit)}
+		if edit.NewContent != "" {
+			replacement.InsertedContent = &SARIFMessage{Text: edit.NewContent}
+		}
+		result.ArtifactChanges[i].Replacements = append(result.ArtifactChanges[i].Replacements, replacement)
+	}
+	return result
+}
+
+// editRegion returns the region an edit replaces. Whole-line edits cover the
+// lines' text; deletions also cover the final line break.
+func (r *SARIFReporter) editRegion(edit rules.Edit) SARIFRegion {
+	endLine := max(edit.EndLine, edit.StartLine)
+
+	if edit.IsRange() {
+		return SARIFRegion{
+			StartLine:   edit.StartLine,
+			StartColumn: r.codePointColumn(edit.File, edit.StartLine, edit.StartColumn),
+			EndLine:     endLine,
+			EndColumn:   r.codePointColumn(edit.File, endLine, edit.EndColumn),
+		}
+	}
+
+	region := SARIFRegion{StartLine: edit.StartLine, StartColumn: 1, EndLine: endLine}
+	lines := r.fileLines(edit.File)
+	switch {
+	case edit.NewContent == "" && endLine < len(lines):
+		region.EndLine = endLine + 1
+		region.EndColumn = 1
+	case endLine <= len(lines):
+		region.EndColumn = utf8.RuneCountInString(lines[endLine-1]) + 1
+	}
+	return region
+}
+
+// codePointColumn converts a 1-based byte column to a 1-based code point
+// column
+func (r *SARIFReporter) codePointColumn(file string, line, column int) int {
+	lines := r.fileLines(file)
+	if line < 1 || line > len(lines) {
+		return column
+	}
+	text := lines[line-1]
+	if column-1 > len(text) {
+		return column
+	}
+	return utf8.RuneCountInString(text[:column-1]) + 1
+}
+
+// fingerprint identifies an issue independently of its line number, so it
+// stays stable when unrelated lines are added or removed. The message is
+// left out since some include counts that change. Identical issues
+// on identical lines are told apart by their order in the file.
+func (r *SARIFReporter) fingerprint(issue rules.Issue, occurrences map[string]int) string {
+	loc := r.artifactLocation(issue.File)
+	var text string
+	if lines := r.fileLines(issue.File); issue.Line >= 1 && issue.Line <= len(lines) {
+		text = strings.Join(strings.Fields(lines[issue.Line-1]), " ")
+	}
+
+	key := strings.Join([]string{issue.Rule, loc.URI, text}, "\x00")
+	occurrences[key]++
+
+	sum := sha256.Sum256([]byte(fmt.Sprintf("%s\x00%d", key, occurrences[key])))
+	return hex.EncodeToString(sum[:16])
+}
+
+// fileLines returns a file's lines, reading it once; unreadable files have
+// no lines
+func (r *SARIFReporter) fileLines(path string) []string {
+	if lines, ok := r.lines[path]; ok {
+		return lines
+	}
+	var lines []string
+	if content, err := os.ReadFile(path); err == nil {
+		lines = strings.Split(string(content), "\n")
+	}
+	r.lines[path] = lines
+	return lines
+}
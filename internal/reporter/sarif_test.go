@@ -0,0 +1,169 @@
+package reporter
+
+import (
+	"bytes"
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/pthm/cclint/internal/rules"
+)
+
+// stubRule provides rule metadata for reporters
+type stubRule struct {
+	name, description string
+}
+
+func (r stubRule) Name() string                                      { return r.name }
+func (r stubRule) Description() string                               { return r.description }
+func (r stubRule) Config() rules.RuleConfig                          { return rules.RuleConfig{} }
+func (r stubRule) Run(*rules.AnalysisContext) ([]rules.Issue, error) { return nil, nil }
+
+func TestSARIFReporter(t *testing.T) {
+	dir := t.TempDir()
+	path := filepath.Join(dir, "CLAUDE.md")
+	content := "# Tïtle\nRead @docs/guid.md\nremove me\nbe careful\nbe careful\n"
+	if err := os.WriteFile(path, []byte(content), 0o644); err != nil {
+		t.Fatal(err)
+	}
+
+	ruleList := []rules.Rule{
+		stubRule{"broken-refs", "Checks for broken file references"},
+		stubRule{"vague-instructions", "Checks for vague instructions"},
+	}
+	issues := []rules.Issue{
+		{
+			Rule: "broken-refs", Severity: rules.Error, Message: "Referenced file not found",
+			File: path, Line: 2, Column: 7,
+			Fix: &rules.Fix{Description: "Change reference", Edits: []rules.Edit{
+				rules.ReplaceText(path, 2, 7, 19, "docs/guide.md"),
+				{File: path, StartLine: 3, EndLine: 3},
+			}},
+		},
+		{Rule: "vague-instructions/vague-guidance", Severity: rules.Suggestion, Message: "Vague", File: path, Line: 4},
+		{Rule: "vague-instructions/vague-guidance", Severity: rules.Suggestion, Message: "Vague", File: path, Line: 5},
+		{Rule: "broken-refs", Severity: rules.Warning, Message: "Heading", File: path, Line: 1, Column: 6, EndLine: 2},
+		{Rule: "long-document", Severity: rules.Info, Message: "Whole file", File: path},
+		{Rule: "broken-refs", Severity: rules.Warning, Message: "Title", File: path, Line: 1, Column: 3, EndColumn: 9},
+	}
+
+	var buf bytes.Buffer
+	if err := NewSARIFReporter(&buf, dir, ruleList).Report(issues); err != nil {
+		t.Fatal(err)
+	}
+	var log SARIFLog
+	if err := json.Unmarshal(buf.Bytes(), &log); err != nil {
+		t.Fatal(err)
+	}
+	if log.Version != "2.1.0" || len(log.Runs) != 1 {
+		t.Fatalf("version %s with %d runs", log.Version, len(log.Runs))
+	}
+	run := log.Runs[0]
+
+	// Registered rules come first, then sub-rules and unknown rules as seen
+	var ids []string
+	for _, rule := range run.Tool.Driver.Rules {
+		ids = append(ids, rule.ID)
+	}
+	wantIDs := []string{"broken-refs", "vague-instructions", "vague-instructions/vague-guidance", "long-document"}
+	if len(ids) != len(wantIDs) {
+		t.Fatalf("rules = %v, want %v", ids, wantIDs)
+	}
+	for i := range wantIDs {
+		if ids[i] != wantIDs[i] {
+			t.Fatalf("rules = %v, want %v", ids, wantIDs)
+		}
+	}
+	sub := run.Tool.Driver.Rules[2]
+	if sub.ShortDescription.Text != "Checks for vague instructions" || sub.Properties["parent"] != "vague-instructions" || sub.Help == nil {
+		t.Errorf("sub-rule descriptor = %+v", sub)
+	}
+
+	if len(run.Results) != len(issues) {
+		t.Fatalf("got %d results, want %d", len(run.Results), len(issues))
+	}
+	wantLevels := []string{"error", "note", "note", "warning", "note", "warning"}
+	for i, result := range run.Results {
+		if result.Level != wantLevels[i] {
+			t.Errorf("result %d level = %s, want %s", i, result.Level, wantLevels[i])
+		}
+		if run.Tool.Driver.Rules[result.RuleIndex].ID != result.RuleID {
+			t.Errorf("result %d ruleIndex %d does not match %s", i, result.RuleIndex, result.RuleID)
+		}
+	}
+
+	loc := run.Results[0].Locations[0].PhysicalLocation
+	if loc.ArtifactLocation.URI != "CLAUDE.md" || loc.ArtifactLocation.URIBaseID != "%SRCROOT%" {
+		t.Errorf("artifact location = %+v", loc.ArtifactLocation)
+	}
+	if *loc.Region != (SARIFRegion{StartLine: 2, StartColumn: 7}) {
+		t.Errorf("region = %+v", *loc.Region)
+	}
+	// Byte column 6 follows the two-byte ï, so it is code point column 5
+	if got := *run.Results[3].Locations[0].PhysicalLocation.Region; got != (SARIFRegion{StartLine: 1, StartColumn: 5, EndLine: 2}) {
+		t.Errorf("multi-line region = %+v", got)
+	}
+	if run.Results[4].Locations[0].PhysicalLocation.Region != nil {
+		t.Error("file-level issue has a region")
+	}
+	// "Tïtle" spans bytes 3-8 but code points 3-7
+	if got := *run.Results[5].Locations[0].PhysicalLocation.Region; got != (SARIFRegion{StartLine: 1, StartColumn: 3, EndColumn: 8}) {
+		t.Errorf("ranged region = %+v", got)
+	}
+
+	fixes := run.Results[0].Fixes
+	if len(fixes) != 1 || len(fixes[0].ArtifactChanges) != 1 {
+		t.Fatalf("fixes = %+v", fixes)
+	}
+	replacements := fixes[0].ArtifactChanges[0].Replacements
+	if len(replacements) != 2 {
+		t.Fatalf("replacements = %+v", replacements)
+	}
+	if replacements[0].DeletedRegion != (SARIFRegion{StartLine: 2, StartColumn: 7, EndLine: 2, EndColumn: 19}) ||
+		replacements[0].InsertedContent == nil || replacements[0].InsertedContent.Text != "docs/guide.md" {
+		t.Errorf("range replacement = %+v", replacements[0])
+	}
+	// Deleting a line removes its line break
+	if replacements[1].DeletedRegion != (SARIFRegion{StartLine: 3, StartColumn: 1, EndLine: 4, EndColumn: 1}) ||
+		replacements[1].InsertedContent != nil {
+		t.Errorf("line deletion = %+v", replacements[1])
+	}
+
+	// Identical issues on identical lines get distinct fingerprints
+	fp := func(i int) string { return run.Results[i].PartialFingerprints[fingerprintKey] }
+	if fp(1) == "" || fp(1) == fp(2) {
+		t.Errorf("fingerprints %q and %q should differ", fp(1), fp(2))
+	}
+}
+
+func TestSARIFFingerprintStable(t *testing.T) {
+	dir := t.TempDir()
+	path := filepath.Join(dir, "CLAUDE.md")
+	ruleList := []rules.Rule{stubRule{"vague-instructions", "Checks for vague instructions"}}
+
+	report := func(content string, line int) string {
+		if err := os.WriteFile(path, []byte(content), 0o644); err != nil {
+			t.Fatal(err)
+		}
+		var buf bytes.Buffer
+		issue := rules.Issue{Rule: "vague-instructions", Severity: rules.Suggestion, Message: "Vague", File: path, Line: line}
+		if err := NewSARIFReporter(&buf, dir, ruleList).Report([]rules.Issue{issue}); err != nil {
+			t.Fatal(err)
+		}
+		var log SARIFLog
+		if err := json.Unmarshal(buf.Bytes(), &log); err != nil {
+			t.Fatal(err)
+		}
+		return log.Runs[0].Results[0].PartialFingerprints[fingerprintKey]
+	}
+
+	before := report("# Title\nbe careful\n", 2)
+	after := report("# Title\n\nIntro paragraph.\nbe  careful\n", 4)
+	if before != after {
+		t.Errorf("fingerprint changed when the line moved: %s != %s", before, after)
+	}
+	if changed := report("# Title\nbe very careful\n", 2); changed == before {
+		t.Error("fingerprint did not change with the line's text")
+	}
+}
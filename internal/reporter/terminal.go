@@ -6,19 +6,23 @@ import (
 	"path/filepath"
 	"sort"
 
+	"github.com/charmbracelet/lipgloss"
 	"github.com/pthm/cclint/internal/rules"
 	"github.com/pthm/cclint/internal/ui"
 )
 
-// TerminalReporter outputs results to the terminal with colors
+// TerminalReporter outputs results to the terminal with colors, showing
+// each issue in a code frame of the source around it
 type TerminalReporter struct {
-	w  io.Writer
-	ui *ui.UI
+	w      io.Writer
+	ui     *ui.UI
+	frames *framePrinter
 }
 
-// NewTerminalReporter creates a new terminal reporter
+// NewTerminalReporter creates a new terminal reporter. Without styling (not a
+// TTY, or NO_COLOR) code frames are drawn in plain ASCII.
 func NewTerminalReporter(w io.Writer, u *ui.UI) *TerminalReporter {
-	return &TerminalReporter{w: w, ui: u}
+	return &TerminalReporter{w: w, ui: u, frames: newFramePrinter(w, u.Styles)}
 }
 
 // Report outputs issues to the terminal
@@ -28,6 +32,8 @@ func (r *TerminalReporter) Report(issues []rules.Issue) error {
 		return nil
 	}
 
+	r.frames.redact(issues)
+
 	// Group issues by file
 	byFile := make(map[string][]rules.Issue)
 	for _, issue := range issues {
@@ -45,9 +51,12 @@ func (r *TerminalReporter) Report(issues []rules.Issue) error {
 	for _, file := range files {
 		fileIssues := byFile[file]
 
-		// Sort issues by line
-		sort.Slice(fileIssues, func(i, j int) bool {
-			return fileIssues[i].Line < fileIssues[j].Line
+		// Sort issues by position
+		sort.SliceStable(fileIssues, func(i, j int) bool {
+			if fileIssues[i].Line != fileIssues[j].Line {
+				return fileIssues[i].Line < fileIssues[j].Line
+			}
+			return fileIssues[i].Column < fileIssues[j].Column
 		})
 
 		// Print file header
@@ -65,33 +74,28 @@ func (r *TerminalReporter) Report(issues []rules.Issue) error {
 	r.printSummary(issues)
 
 	// Return error if there are errors
-	for _, issue := range issues {
-		if issue.Severity == rules.Error {
-			return fmt.Errorf("lint errors found")
-		}
+	if hasErrors(issues) {
+		return fmt.Errorf("lint errors found")
 	}
 
 	return nil
 }
 
 func (r *TerminalReporter) printIssue(issue rules.Issue) {
-	var style *ui.Styles
-	var icon string
-
-	style = r.ui.Styles
+	style := r.ui.Styles
 
+	var icon string
 	switch issue.Severity {
 	case rules.Error:
-		icon = style.Error.Render(style.IconError)
+		icon = style.IconError
 	case rules.Warning:
-		icon = style.Warning.Render(style.IconWarning)
+		icon = style.IconWarning
 	case rules.Suggestion:
-		icon = style.Suggestion.Render(style.IconSuggestion)
-	case rules.Info:
-		icon = style.Info.Render(style.IconInfo)
+		icon = style.IconSuggestion
 	default:
-		icon = style.Info.Render(style.IconInfo)
+		icon = style.IconInfo
 	}
+	icon = severityStyle(style, issue.Severity).Render(icon)
 
 	// Line info
 	lineInfo := ""
@@ -108,10 +112,31 @@ func (r *TerminalReporter) printIssue(issue rules.Issue) {
 	fmt.Fprintln(r.w)
 	fmt.Fprintf(r.w, "    %s\n", issue.Message)
 
-	// Print context if available
+	// Show the source with the location underlined, falling back to the
+	// issue's context when the file cannot be read
+	if r.frames.printIssue(issue, "    ") {
+		return
+	}
 	if issue.Context != "" && len(issue.Context) < 200 {
 		fmt.Fprintf(r.w, "    %s\n", style.Subheader.Render("> "+issue.Context))
 	}
+	if issue.Fix != nil && issue.Fix.Description != "" {
+		fmt.Fprintf(r.w, "    %s %s\n", style.Subheader.Render("= fix:"), issue.Fix.Description)
+	}
+}
+
+// severityStyle returns the style issues of a severity are shown in
+func severityStyle(s *ui.Styles, severity rules.Severity) lipgloss.Style {
+	switch severity {
+	case rules.Error:
+		return s.Error
+	case rules.Warning:
+		return s.Warning
+	case rules.Suggestion:
+		return s.Suggestion
+	default:
+		return s.Info
+	}
 }
 
 func (r *TerminalReporter) printSummary(issues []rules.Issue) {
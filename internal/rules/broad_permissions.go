@@ -5,6 +5,7 @@ import (
 	"fmt"
 	"os"
 	"path/filepath"
+	"strconv"
 	"strings"
 
 	"github.com/pthm/cclint/internal/parser"
@@ -55,17 +56,25 @@ func (r *BroadPermissionsRule) checkSettings(path string, data []byte) []Issue {
 		return issues
 	}
 
+	// Parse for positions so issues point at the offending array element
+	parsed, err := (&parser.JSONParser{}).Parse(path, data)
+	if err != nil {
+		return issues
+	}
+
 	// Check for dangerous glob patterns in allowedTools
 	if allowed, ok := settings["allowedTools"].([]interface{}); ok {
-		for _, tool := range allowed {
+		for i, tool := range allowed {
 			if toolStr, ok := tool.(string); ok {
 				if r.isDangerousPattern(toolStr) {
+					line, column := elementPosition(parsed, parser.JSONPointer("allowedTools", strconv.Itoa(i)))
 					issues = append(issues, Issue{
 						Rule:     r.Name() + "/dangerous-pattern",
 						Severity: Warning,
 						Message:  fmt.Sprintf("Overly broad tool permission: %s", toolStr),
 						File:     path,
-						Line:     1,
+						Line:     line,
+						Column:   column,
 					})
 				}
 			}
@@ -75,15 +84,17 @@ func (r *BroadPermissionsRule) checkSettings(path string, data []byte) []Issue {
 	// Check for dangerous Bash permissions
 	if bash, ok := settings["bash"].(map[string]interface{}); ok {
 		if allowed, ok := bash["allow"].([]interface{}); ok {
-			for _, pattern := range allowed {
+			for i, pattern := range allowed {
 				if patternStr, ok := pattern.(string); ok {
 					if r.isDangerousBashPattern(patternStr) {
+						line, column := elementPosition(parsed, parser.JSONPointer("bash", "allow", strconv.Itoa(i)))
 						issues = append(issues, Issue{
 							Rule:     r.Name() + "/dangerous-bash-pattern",
 							Severity: Warning,
 							Message:  fmt.Sprintf("Overly broad bash permission: %s", patternStr),
 							File:     path,
-							Line:     1,
+							Line:     line,
+							Column:   column,
 						})
 					}
 				}
@@ -94,6 +105,15 @@ func (r *BroadPermissionsRule) checkSettings(path string, data []byte) []Issue {
 	return issues
 }
 
+// elementPosition returns the line and column of the value at pointer, or
+// line 1 if it cannot be found
+func elementPosition(parsed *parser.ParsedFile, pointer string) (int, int) {
+	if node, ok := parsed.Lookup(pointer); ok {
+		return node.ValuePos.Line, node.ValuePos.Column
+	}
+	return 1, 0
+}
+
 func (r *BroadPermissionsRule) isDangerousPattern(pattern string) bool {
 	dangerous := []string{
 		"*",
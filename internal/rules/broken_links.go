@@ -0,0 +1,206 @@
+package rules
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/pthm/cclint/internal/analyzer"
+	"github.com/pthm/cclint/internal/parser"
+)
+
+// BrokenLinksRule checks markdown links and images: relative targets must
+// exist and #fragments must match a heading in the target file
+type BrokenLinksRule struct{}
+
+func (r *BrokenLinksRule) Name() string {
+	return "broken-links"
+}
+
+func (r *BrokenLinksRule) Description() string {
+	return "Checks that markdown link targets and heading anchors exist"
+}
+
+func (r *BrokenLinksRule) Config() RuleConfig {
+	return RuleConfig{} // Applies to every markdown file
+}
+
+func (r *BrokenLinksRule) Run(ctx *AnalysisContext) ([]Issue, error) {
+	var issues []Issue
+
+	anchors := make(map[string][]string) // Target path -> anchors, cached across links
+
+	for _, node := range ctx.AllFiles() {
+		for _, ref := range node.References {
+			if ref.Type != analyzer.RefTypeLink {
+				continue
+			}
+			if issue := r.checkLink(ctx, node, ref, anchors); issue != nil {
+				issues = append(issues, *issue)
+			}
+		}
+	}
+
+	return issues, nil
+}
+
+// checkLink validates a single link, returning an issue if it is broken
+func (r *BrokenLinksRule) checkLink(ctx *AnalysisContext, node *analyzer.ConfigNode, ref analyzer.Reference, anchors map[string][]string) *Issue {
+	if !ref.Resolved {
+		what := "Linked file"
+		if ref.Image {
+			what = "Image"
+		}
+		issue := r.issue(node, ref, "file-not-found", Error, fmt.Sprintf("%s not found: %s", what, ref.Value))
+		if suggestion, ok := closestMatch(filepath.Base(ref.Value), siblingNames(ref.Target)); ok {
+			fixed := filepath.ToSlash(filepath.Join(filepath.Dir(ref.Value), suggestion))
+			issue.Message += fmt.Sprintf(" (did you mean %s?)", fixed)
+			// Only a case-only mismatch is rewritten; a similar name may be a different file
+			if strings.EqualFold(suggestion, filepath.Base(ref.Value)) {
+				r.addReplaceFix(&issue, node, ref, ref.Value, fixed)
+			}
+		}
+		return &issue
+	}
+
+	if ref.Fragment == "" || parser.GetFileType(ref.Target) != parser.FileTypeMarkdown {
+		return nil
+	}
+	if info, err := os.Stat(ref.Target); err != nil || info.IsDir() {
+		return nil
+	}
+
+	available, ok := anchors[ref.Target]
+	if !ok {
+		available = fileAnchors(ctx, ref.Target)
+		anchors[ref.Target] = available
+	}
+	for _, anchor := range available {
+		if strings.EqualFold(anchor, ref.Fragment) {
+			return nil
+		}
+	}
+
+	where := "this file"
+	if ref.Value != "" {
+		where = ref.Value
+	}
+	issue := r.issue(node, ref, "anchor-not-found", Warning, fmt.Sprintf("No heading for anchor #%s in %s", ref.Fragment, where))
+	if suggestion, ok := closestMatch(strings.ToLower(ref.Fragment), available); ok {
+		issue.Message += fmt.Sprintf(" (did you mean #%s?)", suggestion)
+	}
+	return &issue
+}
+
+// issue builds an issue located at a link
+func (r *BrokenLinksRule) issue(node *analyzer.ConfigNode, ref analyzer.Reference, subRule string, severity Severity, message string) Issue {
+	return Issue{
+		Rule:     r.Name() + "/" + subRule,
+		Severity: severity,
+		Message:  message,
+		File:     node.Path,
+		Line:     ref.Source.Line,
+		Column:   ref.Source.Column,
+		Context:  strings.TrimSpace(lineAt(node.Content, ref.Source.Line)),
+	}
+}
+
+// addReplaceFix attaches a fix replacing old with replacement in the link's
+// destination on its line
+func (r *BrokenLinksRule) addReplaceFix(issue *Issue, node *analyzer.ConfigNode, ref analyzer.Reference, old, replacement string) {
+	line := lineAt(node.Content, ref.Source.Line)
+	from := ref.Source.Column - 1
+	if from < 0 || from > len(line) {
+		return
+	}
+	idx := strings.Index(line[from:], old)
+	if idx < 0 {
+		return
+	}
+	idx += from
+	issue.Fix = &Fix{
+		Description: fmt.Sprintf("Change link target to %s", replacement),
+		Edits:       []Edit{ReplaceText(node.Path, ref.Source.Line, idx+1, idx+1+len(old), replacement)},
+	}
+}
+
+// fileAnchors returns the anchors a markdown file defines, parsing it if it
+// is not already in the tree
+func fileAnchors(ctx *AnalysisContext, path string) []string {
+	if node, ok := ctx.Tree.Nodes[path]; ok && node.Parsed != nil {
+		return node.Parsed.Anchors()
+	}
+	parsed, err := parser.Parse(path)
+	if err != nil {
+		return nil
+	}
+	return parsed.Anchors()
+}
+
+// siblingNames lists the entries in the directory that would contain path
+func siblingNames(path string) []string {
+	entries, err := os.ReadDir(filepath.Dir(path))
+	if err != nil {
+		return nil
+	}
+	names := make([]string, 0, len(entries))
+	for _, entry := range entries {
+		names = append(names, entry.Name())
+	}
+	return names
+}
+
+// lineAt returns the 1-based line of content, or "" if out of range
+func lineAt(content []byte, line int) string {
+	lines := strings.Split(string(content), "\n")
+	if line < 1 || line > len(lines) {
+		return ""
+	}
+	return lines[line-1]
+}
+
+// closestMatch returns the candidate nearest to s by edit distance, if it is
+// close enough to be a plausible typo
+func closestMatch(s string, candidates []string) (string, bool) {
+	best, bestDistance := "", -1
+	for _, c := range candidates {
+		d := levenshtein(strings.ToLower(s), strings.ToLower(c))
+		if bestDistance < 0 || d < bestDistance {
+			best, bestDistance = c, d
+		}
+	}
+
+	threshold := len(s) / 3
+	if threshold < 2 {
+		threshold = 2
+	}
+	if bestDistance < 0 || bestDistance > threshold {
+		return "", false
+	}
+	return best, true
+}
+
+// levenshtein returns the edit distance between a and b
+func levenshtein(a, b string) int {
+	ra, rb := []rune(a), []rune(b)
+	prev := make([]int, len(rb)+1)
+	curr := make([]int, len(rb)+1)
+	for j := range prev {
+		prev[j] = j
+	}
+
+	for i := 1; i <= len(ra); i++ {
+		curr[0] = i
+		for j := 1; j <= len(rb); j++ {
+			cost := 1
+			if ra[i-1] == rb[j-1] {
+				cost = 0
+			}
+			curr[j] = min(prev[j]+1, curr[j-1]+1, prev[j-1]+cost)
+		}
+		prev, curr = curr, prev
+	}
+
+	return prev[len(rb)]
+}
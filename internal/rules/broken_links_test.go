@@ -0,0 +1,121 @@
+package rules
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/pthm/cclint/internal/agent"
+	"github.com/pthm/cclint/internal/analyzer"
+)
+
+func TestBrokenLinksRule_Run(t *testing.T) {
+	tmpDir, err := os.MkdirTemp("", "broken-links-test-*")
+	if err != nil {
+		t.Fatalf("Failed to create temp dir: %v", err)
+	}
+	defer os.RemoveAll(tmpDir)
+
+	files := map[string]string{
+		"CLAUDE.md": `# Main Agent
+
+## Conventions
+
+- See [setup](docs/guide.md#setup) and [install](docs/guide.md#instal).
+- Read [the changelog](docs/changlog.md).
+- Jump to [conventions](#conventions) or [testing](#testing).
+- Visit [the site](https://example.com) and ![logo](img/logo.png).
+- Read [the guide](docs/Guide.md).
+- Links without text: [](missing.md) and ![](nope.png).
+- Encoded anchors: [café](docs/guide.md#caf%C3%A9) and [step 2](docs/guide.md#step-%32).
+`,
+		"docs/guide.md": `# Guide
+
+## Setup
+
+## Install
+
+## Café
+
+## Step 2
+`,
+		"docs/changelog.md": "# Changelog",
+	}
+
+	for name, content := range files {
+		path := filepath.Join(tmpDir, name)
+		if err := os.MkdirAll(filepath.Dir(path), 0o755); err != nil {
+			t.Fatalf("Failed to create dir for %s: %v", name, err)
+		}
+		if err := os.WriteFile(path, []byte(content), 0o644); err != nil {
+			t.Fatalf("Failed to write file %s: %v", name, err)
+		}
+	}
+
+	agentConfig, err := agent.Load("claude-code")
+	if err != nil {
+		t.Fatalf("Failed to load agent config: %v", err)
+	}
+
+	tree, err := analyzer.BuildTree(tmpDir, agentConfig)
+	if err != nil {
+		t.Fatalf("Failed to build tree: %v", err)
+	}
+
+	ctx := &AnalysisContext{
+		Tree:        tree,
+		AgentConfig: agentConfig,
+		RootPath:    tmpDir,
+	}
+
+	rule := &BrokenLinksRule{}
+	issues, err := rule.Run(ctx)
+	if err != nil {
+		t.Fatalf("Run() returned error: %v", err)
+	}
+
+	var messages []string
+	for _, issue := range issues {
+		messages = append(messages, issue.Message)
+	}
+
+	want := []string{
+		"No heading for anchor #instal in docs/guide.md (did you mean #install?)",
+		"Linked file not found: docs/changlog.md (did you mean docs/changelog.md?)",
+		"No heading for anchor #testing in this file",
+		"Image not found: img/logo.png",
+		"Linked file not found: docs/Guide.md (did you mean docs/guide.md?)",
+		"Linked file not found: missing.md",
+		"Image not found: nope.png",
+	}
+	if len(issues) != len(want) {
+		t.Fatalf("got %d issues %v, want %d", len(issues), messages, len(want))
+	}
+	for _, w := range want {
+		found := false
+		for _, m := range messages {
+			if m == w {
+				found = true
+			}
+		}
+		if !found {
+			t.Errorf("Expected issue %q, got %v", w, messages)
+		}
+	}
+
+	// Only a case-only mismatch is fixed; near-misses are just suggested
+	for _, issue := range issues {
+		switch {
+		case strings.Contains(issue.Message, "docs/Guide.md"):
+			if issue.Line != 9 || issue.Fix == nil || len(issue.Fix.Edits) != 1 {
+				t.Fatalf("case issue = %+v, want line 9 with a fix", issue)
+			}
+			if got := editedLine(t, files["CLAUDE.md"], issue.Fix.Edits[0]); !strings.Contains(got, "(docs/guide.md)") {
+				t.Errorf("fix content = %q, want corrected case", got)
+			}
+		case issue.Fix != nil:
+			t.Errorf("issue %q should have no fix", issue.Message)
+		}
+	}
+}
@@ -28,11 +28,16 @@ func (r *BrokenRefsRule) Config() RuleConfig {
 func (r *BrokenRefsRule) Run(ctx *AnalysisContext) ([]Issue, error) {
 	var issues []Issue
 
+	suggester := newPathSuggester(ctx.RootPath)
+
 	for _, node := range ctx.AllFiles() {
 		for _, ref := range node.References {
 			switch ref.Type {
 			case analyzer.RefTypeFile:
-				if issue := r.checkFileRef(ref, ctx.RootPath); issue != nil {
+				if ref.Origin == analyzer.RefOriginLink {
+					continue // Markdown links are checked by broken-links
+				}
+				if issue := r.checkFileRef(ref, ctx.RootPath, suggester); issue != nil {
 					issues = append(issues, *issue)
 				}
 			case analyzer.RefTypeURL:
@@ -46,41 +51,73 @@ func (r *BrokenRefsRule) Run(ctx *AnalysisContext) ([]Issue, error) {
 	return issues, nil
 }
 
-func (r *BrokenRefsRule) checkFileRef(ref analyzer.Reference, rootPath string) *Issue {
-	// Try to resolve the file path
-	refPath := strings.TrimPrefix(ref.Value, "@")
-
-	// Try relative to source file
-	var resolvedPath string
-	if filepath.IsAbs(refPath) {
-		resolvedPath = refPath
-	} else {
-		// Try relative to source file directory
-		sourceDir := filepath.Dir(ref.Source.File)
-		resolvedPath = filepath.Join(sourceDir, refPath)
+func (r *BrokenRefsRule) checkFileRef(ref analyzer.Reference, rootPath string, suggester *pathSuggester) *Issue {
+	target, _, ok := analyzer.ResolveReference(rootPath, ref)
+	if ok {
+		return nil
 	}
 
-	// Check if file exists
-	if _, err := os.Stat(resolvedPath); err != nil {
-		// Try relative to root
-		rootRelative := filepath.Join(rootPath, refPath)
-		if _, err := os.Stat(rootRelative); err != nil {
-			return &Issue{
-				Rule:     r.Name() + "/file-not-found",
-				Severity: Error,
-				Message:  fmt.Sprintf("Referenced file not found: %s", ref.Value),
-				File:     ref.Source.File,
-				Line:     ref.Source.Line,
-				Column:   ref.Source.Column,
-				Context:  ref.Context,
-				Fix: &Fix{
-					Description: "Remove or update the broken reference",
-				},
-			}
+	message := fmt.Sprintf("Referenced file not found: %s", ref.Value)
+	if ref.Import && !filepath.IsAbs(ref.Value) {
+		// A common mistake: writing imports relative to the project root
+		if _, err := os.Stat(filepath.Join(rootPath, ref.Value)); err == nil {
+			message = fmt.Sprintf("Imported file not found: %s (imports resolve relative to the importing file, but it exists relative to the project root)", ref.Value)
 		}
 	}
 
-	return nil
+	issue := &Issue{
+		Rule:      r.Name() + "/file-not-found",
+		Severity:  Error,
+		Message:   message,
+		File:      ref.Source.File,
+		Line:      ref.Source.Line,
+		Column:    ref.Source.Column,
+		EndColumn: ref.EndColumn,
+		Context:   ref.Context,
+	}
+
+	if target == "" {
+		return issue
+	}
+	suggestion, found := suggester.suggest(target)
+	if !found {
+		return issue
+	}
+	replacement := referencePath(rootPath, ref, suggestion.path)
+	issue.Message += fmt.Sprintf(" (did you mean %s? %s)", replacement, suggestion.reason)
+
+	// Only unambiguous matches are rewritten; a similar name may be a different file
+	if suggestion.certain && ref.EndColumn > ref.Source.Column {
+		issue.Fix = &Fix{
+			Description: fmt.Sprintf("Change reference to %s", replacement),
+			Edits:       []Edit{ReplaceText(ref.Source.File, ref.Source.Line, ref.Source.Column, ref.EndColumn, replacement)},
+		}
+	}
+
+	return issue
+}
+
+// referencePath writes path the way ref was written: imports relative to the
+// importing file, other references relative to the project root (keeping a
+// leading / or ./ if the original had one)
+func referencePath(rootPath string, ref analyzer.Reference, path string) string {
+	base := rootPath
+	if ref.Import {
+		base = filepath.Dir(ref.Source.File)
+	}
+	rel, err := filepath.Rel(base, path)
+	if err != nil {
+		return path
+	}
+	rel = filepath.ToSlash(rel)
+
+	switch {
+	case strings.HasPrefix(ref.Value, "/") && !ref.Import:
+		return "/" + rel
+	case strings.HasPrefix(ref.Value, "./") && !strings.HasPrefix(rel, "../"):
+		return "./" + rel
+	}
+	return rel
 }
 
 func (r *BrokenRefsRule) checkURLRef(ref analyzer.Reference) *Issue {
@@ -88,13 +125,14 @@ func (r *BrokenRefsRule) checkURLRef(ref analyzer.Reference) *Issue {
 	_, err := url.ParseRequestURI(ref.Value)
 	if err != nil {
 		return &Issue{
-			Rule:     r.Name() + "/invalid-url-format",
-			Severity: Error,
-			Message:  fmt.Sprintf("Invalid URL format: %s", ref.Value),
-			File:     ref.Source.File,
-			Line:     ref.Source.Line,
-			Column:   ref.Source.Column,
-			Context:  ref.Context,
+			Rule:      r.Name() + "/invalid-url-format",
+			Severity:  Error,
+			Message:   fmt.Sprintf("Invalid URL format: %s", ref.Value),
+			File:      ref.Source.File,
+			Line:      ref.Source.Line,
+			Column:    ref.Source.Column,
+			EndColumn: ref.EndColumn,
+			Context:   ref.Context,
 		}
 	}
 
@@ -0,0 +1,151 @@
+package rules
+
+import (
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/pthm/cclint/internal/agent"
+	"github.com/pthm/cclint/internal/analyzer"
+)
+
+func TestBrokenRefsRule_Suggestions(t *testing.T) {
+	tmpDir := t.TempDir()
+
+	files := map[string]string{
+		"CLAUDE.md": `# Main Agent
+
+- Read @docs/Guide.md first
+- Follow @old/conventions.md
+- Testing lives in ` + "`docs/testing.md`" + `
+- Architecture: ` + "`/docs/architecure.md`" + `
+- Nothing like ` + "`zzz/qqq.md`" + `
+- Setup: @docs/setup-guide.md
+`,
+		"docs/guide.md":        "# Guide",
+		"guides/testing.md":    "# Testing",
+		"docs/architecture.md": "# Architecture",
+		"docs/style-guide.md":  "# Style",
+		"old/conventions.md":   "# Conventions",
+	}
+
+	for name, content := range files {
+		path := filepath.Join(tmpDir, name)
+		if err := os.MkdirAll(filepath.Dir(path), 0o755); err != nil {
+			t.Fatalf("Failed to create dir for %s: %v", name, err)
+		}
+		if err := os.WriteFile(path, []byte(content), 0o644); err != nil {
+			t.Fatalf("Failed to write file %s: %v", name, err)
+		}
+	}
+
+	// Move old/conventions.md in git history so only the rename points to it
+	hasGit := false
+	if _, err := exec.LookPath("git"); err == nil {
+		hasGit = true
+		if err := os.MkdirAll(filepath.Join(tmpDir, "docs/style"), 0o755); err != nil {
+			t.Fatalf("Failed to create dir: %v", err)
+		}
+		for _, args := range [][]string{
+			{"init", "-q"},
+			{"add", "-A"},
+			{"commit", "-q", "-m", "initial"},
+			{"mv", "old/conventions.md", "docs/style/conventions-v2.md"},
+			{"commit", "-q", "-m", "move conventions"},
+		} {
+			cmd := exec.Command("git", append([]string{"-C", tmpDir, "-c", "user.name=test", "-c", "user.email=test@example.com"}, args...)...)
+			if out, err := cmd.CombinedOutput(); err != nil {
+				t.Fatalf("git %v failed: %v: %s", args, err, out)
+			}
+		}
+	}
+
+	agentConfig, err := agent.Load("claude-code")
+	if err != nil {
+		t.Fatalf("Failed to load agent config: %v", err)
+	}
+
+	tree, err := analyzer.BuildTree(tmpDir, agentConfig)
+	if err != nil {
+		t.Fatalf("Failed to build tree: %v", err)
+	}
+
+	ctx := &AnalysisContext{
+		Tree:        tree,
+		AgentConfig: agentConfig,
+		RootPath:    tmpDir,
+	}
+
+	rule := &BrokenRefsRule{}
+	issues, err := rule.Run(ctx)
+	if err != nil {
+		t.Fatalf("Run() returned error: %v", err)
+	}
+
+	byLine := make(map[int]Issue)
+	for _, issue := range issues {
+		byLine[issue.Line] = issue
+	}
+
+	// Similar spellings are only suggested: the file may be a different one
+	tests := []struct {
+		line    int
+		reason  string
+		newLine string // "" if the suggestion carries no fix
+	}{
+		{3, "case differs", "- Read @docs/guide.md first"},
+		{4, "renamed in git history", "- Follow @docs/style/conventions-v2.md"},
+		{5, "with that name exists elsewhere", "- Testing lives in `guides/testing.md`"},
+		{6, "did you mean /docs/architecture.md? a similarly named file exists", ""},
+		{8, "similarly named", ""},
+	}
+	for _, tt := range tests {
+		if tt.line == 4 && !hasGit {
+			continue
+		}
+		issue, ok := byLine[tt.line]
+		if !ok {
+			t.Errorf("Expected an issue on line %d", tt.line)
+			continue
+		}
+		if !strings.Contains(issue.Message, tt.reason) {
+			t.Errorf("Line %d: message %q should mention %q", tt.line, issue.Message, tt.reason)
+		}
+		if tt.newLine == "" {
+			if issue.Fix != nil {
+				t.Errorf("Line %d: expected no fix for a similar name, got %+v", tt.line, issue.Fix)
+			}
+			continue
+		}
+		if issue.Fix == nil || len(issue.Fix.Edits) != 1 {
+			t.Errorf("Line %d: expected a single-edit fix, got %+v", tt.line, issue.Fix)
+			continue
+		}
+		if got := editedLine(t, files["CLAUDE.md"], issue.Fix.Edits[0]); got != tt.newLine {
+			t.Errorf("Line %d: fix = %q, want %q", tt.line, got, tt.newLine)
+		}
+	}
+
+	unknown, ok := byLine[7]
+	if !ok {
+		t.Fatal("Expected an issue for zzz/qqq.md")
+	}
+	if unknown.Fix != nil {
+		t.Errorf("No fix expected without a candidate, got %+v", unknown.Fix)
+	}
+}
+
+// editedLine applies a single-line range edit to its line of content
+func editedLine(t *testing.T, content string, edit Edit) string {
+	t.Helper()
+	if !edit.IsRange() || edit.StartLine != edit.EndLine {
+		t.Fatalf("Expected a single-line range edit, got %+v", edit)
+	}
+	line := strings.Split(content, "\n")[edit.StartLine-1]
+	if edit.StartColumn < 1 || edit.EndColumn > len(line)+1 || edit.EndColumn < edit.StartColumn {
+		t.Fatalf("Edit columns %d-%d out of range for %q", edit.StartColumn, edit.EndColumn, line)
+	}
+	return line[:edit.StartColumn-1] + edit.NewContent + line[edit.EndColumn-1:]
+}
@@ -35,6 +35,8 @@ func (r *DuplicateInstructionsRule) Run(ctx *AnalysisContext) ([]Issue, error) {
 	type sectionInfo struct {
 		file    string
 		title   string
+		line    int
+		endLine int
 		content string
 		hash    string
 	}
@@ -60,6 +62,8 @@ func (r *DuplicateInstructionsRule) Run(ctx *AnalysisContext) ([]Issue, error) {
 			sections = append(sections, sectionInfo{
 				file:    node.Path,
 				title:   section.Title,
+				line:    section.StartLine,
+				endLine: section.EndLine,
 				content: normalized,
 				hash:    hash,
 			})
@@ -81,8 +85,14 @@ func (r *DuplicateInstructionsRule) Run(ctx *AnalysisContext) ([]Issue, error) {
 						existing.title,
 						existing.file,
 					),
-					File: section.file,
-					Line: 1,
+					File:    section.file,
+					Line:    max(section.line, 1),
+					EndLine: section.endLine,
+					Related: []Location{{
+						File:    existing.file,
+						Line:    existing.line,
+						Message: "first defined here",
+					}},
 				})
 			}
 		} else {
@@ -114,8 +124,14 @@ func (r *DuplicateInstructionsRule) Run(ctx *AnalysisContext) ([]Issue, error) {
 						sections[j].title,
 						sections[j].file,
 					),
-					File: sections[i].file,
-					Line: 1,
+					File:    sections[i].file,
+					Line:    max(sections[i].line, 1),
+					EndLine: sections[i].endLine,
+					Related: []Location{{
+						File:    sections[j].file,
+						Line:    sections[j].line,
+						Message: "similar section",
+					}},
 				})
 			}
 		}
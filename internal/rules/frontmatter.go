@@ -0,0 +1,182 @@
+package rules
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/pthm/cclint/internal/agent"
+	"github.com/pthm/cclint/internal/analyzer"
+	"github.com/pthm/cclint/internal/parser"
+)
+
+// FrontmatterRule validates the frontmatter of subagents, skills and commands
+// against the schemas in the agent configuration
+type FrontmatterRule struct{}
+
+func (r *FrontmatterRule) Name() string {
+	return "frontmatter"
+}
+
+func (r *FrontmatterRule) Description() string {
+	return "Validates subagent, skill and command frontmatter fields"
+}
+
+func (r *FrontmatterRule) Config() RuleConfig {
+	return RuleConfig{} // Schemas are selected by scope type
+}
+
+func (r *FrontmatterRule) Run(ctx *AnalysisContext) ([]Issue, error) {
+	var issues []Issue
+
+	scopes, err := ctx.Scopes()
+	if err != nil {
+		return nil, err
+	}
+
+	for _, scope := range FlattenScopes(scopes) {
+		schema, ok := ctx.AgentConfig.Frontmatter[scope.Type.String()]
+		if !ok {
+			continue
+		}
+		node := ctx.Tree.Nodes[scope.Entrypoint]
+		if node == nil || node.Content == nil {
+			continue
+		}
+		issues = append(issues, r.checkFile(ctx.AgentConfig, scope, node, schema)...)
+	}
+
+	return issues, nil
+}
+
+// checkFile validates one file's frontmatter against schema
+func (r *FrontmatterRule) checkFile(agentConfig *agent.Config, scope *analyzer.ContextScope, node *analyzer.ConfigNode, schema agent.FrontmatterSchema) []Issue {
+	var issues []Issue
+
+	issue := func(subRule string, severity Severity, line int, message string) {
+		issues = append(issues, Issue{
+			Rule:     r.Name() + "/" + subRule,
+			Severity: severity,
+			Message:  message,
+			File:     node.Path,
+			Line:     line,
+		})
+	}
+
+	kind := scope.Type.String()
+	fm := parser.ParseFrontmatterBlock(node.Content)
+	if fm == nil {
+		if len(schema.Required) > 0 {
+			issue("missing-frontmatter", Error, 1, fmt.Sprintf("The %s has no frontmatter; required fields: %s", kind, strings.Join(schema.Required, ", ")))
+		}
+		return issues
+	}
+	if fm.Err != nil {
+		issue("invalid-yaml", Error, fm.ErrLine, fmt.Sprintf("Invalid frontmatter YAML: %v", fm.Err))
+		return issues
+	}
+
+	for _, field := range schema.Required {
+		if isEmptyValue(fm.Fields[field]) {
+			issue("missing-field", Error, fm.StartLine, fmt.Sprintf("The %s frontmatter is missing required field '%s'", kind, field))
+		}
+	}
+
+	for _, key := range fm.Keys() {
+		fieldType, known := schema.Fields[key]
+		if !known {
+			issue("unknown-field", Warning, fm.KeyLine(key), fmt.Sprintf("Unknown %s frontmatter field '%s'", kind, key))
+			continue
+		}
+		if message := checkFieldType(agentConfig, schema, key, fieldType, fm.Fields[key]); message != "" {
+			subRule := "invalid-value"
+			if fieldType == "model" {
+				subRule = "invalid-model"
+			}
+			issue(subRule, Error, fm.KeyLine(key), message)
+		}
+	}
+
+	if name, ok := fm.Fields["name"].(string); ok && schema.NameMatchesFile && name != "" {
+		if expected := unqualifiedName(scope); name != expected {
+			issue("name-mismatch", Warning, fm.KeyLine("name"), fmt.Sprintf("The %s name '%s' does not match its file or directory name '%s'", kind, name, expected))
+		}
+	}
+
+	return issues
+}
+
+// checkFieldType returns a message if value does not match fieldType
+func checkFieldType(agentConfig *agent.Config, schema agent.FrontmatterSchema, key, fieldType string, value interface{}) string {
+	switch fieldType {
+	case "string":
+		if _, ok := value.(string); !ok {
+			return fmt.Sprintf("Field '%s' must be a string", key)
+		}
+	case "bool":
+		if _, ok := value.(bool); !ok {
+			return fmt.Sprintf("Field '%s' must be true or false", key)
+		}
+	case "list":
+		switch v := value.(type) {
+		case string:
+		case []interface{}:
+			for _, item := range v {
+				if _, ok := item.(string); !ok {
+					return fmt.Sprintf("Field '%s' must be a list of strings", key)
+				}
+			}
+		default:
+			return fmt.Sprintf("Field '%s' must be a list or a comma-separated string", key)
+		}
+	case "model":
+		model, _ := value.(string)
+		if !isKnownModel(agentConfig.Models, model) {
+			return fmt.Sprintf("Unknown model '%v'; use one of %s or a full model ID", value, strings.Join(agentConfig.Models.Aliases, ", "))
+		}
+	case "color":
+		color, _ := value.(string)
+		for _, c := range schema.Colors {
+			if color == c {
+				return ""
+			}
+		}
+		return fmt.Sprintf("Unknown color '%v'; use one of %s", value, strings.Join(schema.Colors, ", "))
+	}
+	return ""
+}
+
+// isKnownModel reports whether model is an accepted alias or full model ID
+func isKnownModel(models agent.ModelNames, model string) bool {
+	for _, alias := range models.Aliases {
+		if model == alias {
+			return true
+		}
+	}
+	for _, prefix := range models.Prefixes {
+		if strings.HasPrefix(model, prefix) && len(model) > len(prefix) {
+			return true
+		}
+	}
+	return false
+}
+
+// isEmptyValue reports whether a frontmatter value is missing or blank
+func isEmptyValue(value interface{}) bool {
+	if value == nil {
+		return true
+	}
+	if s, ok := value.(string); ok {
+		return strings.TrimSpace(s) == ""
+	}
+	return false
+}
+
+// unqualifiedName returns the name discovery gave a scope from its location
+// (a subagent's file or directory, a skill's directory), without the plugin
+// namespace
+func unqualifiedName(scope *analyzer.ContextScope) string {
+	if scope.Plugin != "" {
+		return strings.TrimPrefix(scope.Name, scope.Plugin+":")
+	}
+	return scope.Name
+}
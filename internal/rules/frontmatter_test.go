@@ -0,0 +1,115 @@
+package rules
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/pthm/cclint/internal/agent"
+	"github.com/pthm/cclint/internal/analyzer"
+)
+
+func TestFrontmatterRule_Run(t *testing.T) {
+	tmpDir, err := os.MkdirTemp("", "frontmatter-test-*")
+	if err != nil {
+		t.Fatalf("Failed to create temp dir: %v", err)
+	}
+	defer os.RemoveAll(tmpDir)
+
+	files := map[string]string{
+		"CLAUDE.md": "# Main Agent",
+		".claude/agents/reviewer.md": `---
+name: code-reviewer
+description: Reviews code
+model: gpt-4
+colour: red
+---
+
+Review the diff.
+`,
+		".claude/agents/tester.md": `---
+name: tester
+description: Runs tests
+model: claude-sonnet-4-5
+tools: Read, Bash
+---
+
+Run the tests.
+`,
+		".claude/agents/planner/CLAUDE.md": `---
+name: planner
+description: Plans the work
+---
+
+Plan first.
+`,
+		".claude/skills/deploy/SKILL.md": `---
+name: deploy
+description: a: b
+---
+`,
+		".claude/commands/ship.md": `---
+allowed-tools: 42
+---
+
+Ship it.
+`,
+	}
+
+	for name, content := range files {
+		path := filepath.Join(tmpDir, name)
+		if err := os.MkdirAll(filepath.Dir(path), 0o755); err != nil {
+			t.Fatalf("Failed to create dir for %s: %v", name, err)
+		}
+		if err := os.WriteFile(path, []byte(content), 0o644); err != nil {
+			t.Fatalf("Failed to write file %s: %v", name, err)
+		}
+	}
+
+	agentConfig, err := agent.Load("claude-code")
+	if err != nil {
+		t.Fatalf("Failed to load agent config: %v", err)
+	}
+
+	tree, err := analyzer.BuildTree(tmpDir, agentConfig)
+	if err != nil {
+		t.Fatalf("Failed to build tree: %v", err)
+	}
+
+	ctx := &AnalysisContext{
+		Tree:        tree,
+		AgentConfig: agentConfig,
+		RootPath:    tmpDir,
+	}
+
+	rule := &FrontmatterRule{}
+	issues, err := rule.Run(ctx)
+	if err != nil {
+		t.Fatalf("Run() returned error: %v", err)
+	}
+
+	type key struct {
+		rule string
+		line int
+	}
+	found := make(map[key]bool)
+	for _, issue := range issues {
+		// A directory-form subagent is named after its directory
+		if filepath.Base(issue.File) == "tester.md" || filepath.Base(filepath.Dir(issue.File)) == "planner" {
+			t.Errorf("Valid subagent should have no issues, got %v", issue)
+		}
+		found[key{issue.Rule, issue.Line}] = true
+	}
+
+	for _, want := range []key{
+		{"frontmatter/name-mismatch", 2},
+		{"frontmatter/invalid-model", 4},
+		{"frontmatter/unknown-field", 5},
+		{"frontmatter/invalid-yaml", 3},
+		{"frontmatter/invalid-value", 2},
+	} {
+		if !found[want] {
+			t.Errorf("Expected issue %s at line %d, got %v", want.rule, want.line, issues)
+		}
+	}
+}
@@ -0,0 +1,123 @@
+package rules
+
+import (
+	"fmt"
+	"path/filepath"
+	"strings"
+
+	"github.com/pthm/cclint/internal/analyzer"
+)
+
+// ImportDepthRule flags imports that the agent silently drops because they
+// are further from an entrypoint than its maximum import depth
+type ImportDepthRule struct{}
+
+func (r *ImportDepthRule) Name() string {
+	return "import-depth"
+}
+
+func (r *ImportDepthRule) Description() string {
+	return "Checks for imports nested too deeply to be loaded"
+}
+
+func (r *ImportDepthRule) Config() RuleConfig {
+	return RuleConfig{} // Applies to all file types
+}
+
+func (r *ImportDepthRule) Run(ctx *AnalysisContext) ([]Issue, error) {
+	var issues []Issue
+	if ctx.Tree == nil || ctx.Tree.Root == nil {
+		return issues, nil
+	}
+
+	maxDepth := ctx.AgentConfig.MaxImportDepth()
+
+	// Breadth-first search gives each file its shortest import chain, which
+	// is the one the agent loads it through
+	hops := make(map[string]int)
+	chain := make(map[string]string) // Path -> file that imports it
+	var queue []*analyzer.ConfigNode
+	for _, entry := range ctx.Tree.Root.Children {
+		if _, seen := hops[entry.Path]; !seen {
+			hops[entry.Path] = 0
+			queue = append(queue, entry)
+		}
+	}
+
+	reported := make(map[string]bool)
+	for len(queue) > 0 {
+		node := queue[0]
+		queue = queue[1:]
+
+		for _, ref := range node.References {
+			if !ref.Import || !ref.Resolved {
+				continue
+			}
+			if _, seen := hops[ref.Target]; seen {
+				continue
+			}
+
+			if hops[node.Path]+1 > maxDepth {
+				key := fmt.Sprintf("%s:%d:%d", ref.Source.File, ref.Source.Line, ref.Source.Column)
+				if !reported[key] {
+					reported[key] = true
+					issues = append(issues, r.droppedImport(ctx, ref, chainTo(node.Path, chain), maxDepth))
+				}
+				continue
+			}
+
+			hops[ref.Target] = hops[node.Path] + 1
+			chain[ref.Target] = node.Path
+			if child, ok := ctx.Tree.Nodes[ref.Target]; ok {
+				queue = append(queue, child)
+			}
+		}
+	}
+
+	return issues, nil
+}
+
+// droppedImport builds the issue for an import beyond the depth limit
+func (r *ImportDepthRule) droppedImport(ctx *AnalysisContext, ref analyzer.Reference, path []string, maxDepth int) Issue {
+	names := make([]string, len(path))
+	for i, p := range path {
+		names[i] = relPath(ctx.RootPath, p)
+	}
+
+	return Issue{
+		Rule:     r.Name(),
+		Severity: Warning,
+		Message: fmt.Sprintf("Import of %s will not be loaded: it is %d imports deep and Claude Code follows at most %d (chain: %s)",
+			ref.Value, len(path), maxDepth, strings.Join(names, " -> ")),
+		File:      ref.Source.File,
+		Line:      ref.Source.Line,
+		Column:    ref.Source.Column,
+		EndColumn: ref.EndColumn,
+		Context:   ref.Context,
+		Fix: &Fix{
+			Description: fmt.Sprintf("Import %s from a file closer to the entrypoint", ref.Value),
+		},
+	}
+}
+
+// chainTo returns the import chain from an entrypoint to path
+func chainTo(path string, chain map[string]string) []string {
+	result := []string{path}
+	for {
+		parent, ok := chain[path]
+		if !ok {
+			break
+		}
+		result = append([]string{parent}, result...)
+		path = parent
+	}
+	return result
+}
+
+// relPath returns path relative to root, or path itself if that fails
+func relPath(root, path string) string {
+	if rel, err := filepath.Rel(root, path); err == nil {
+		return rel
+	}
+	return path
+}
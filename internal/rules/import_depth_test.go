@@ -0,0 +1,87 @@
+package rules
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/pthm/cclint/internal/agent"
+	"github.com/pthm/cclint/internal/analyzer"
+)
+
+func TestImportDepthRule_Run(t *testing.T) {
+	tmpDir, err := os.MkdirTemp("", "import-depth-test-*")
+	if err != nil {
+		t.Fatalf("Failed to create temp dir: %v", err)
+	}
+	defer os.RemoveAll(tmpDir)
+
+	// CLAUDE.md -> 1 -> 2 -> 3 -> 4 -> 5 is allowed; 5 -> 6 is one hop too many.
+	// Each import is relative to the importing file.
+	files := map[string]string{
+		"CLAUDE.md":           "# Main\n\n@docs/level1.md\n",
+		"docs/level1.md":      "@level2.md\n",
+		"docs/level2.md":      "@deep/level3.md\n",
+		"docs/deep/level3.md": "@../level4.md\n",
+		"docs/level4.md":      "@level5.md and @level2.md again\n",
+		"docs/level5.md":      "Also see @level6.md\n",
+		"docs/level6.md":      "# Never loaded\n",
+	}
+
+	for name, content := range files {
+		path := filepath.Join(tmpDir, name)
+		if err := os.MkdirAll(filepath.Dir(path), 0o755); err != nil {
+			t.Fatalf("Failed to create dir for %s: %v", name, err)
+		}
+		if err := os.WriteFile(path, []byte(content), 0o644); err != nil {
+			t.Fatalf("Failed to write file %s: %v", name, err)
+		}
+	}
+
+	agentConfig, err := agent.Load("claude-code")
+	if err != nil {
+		t.Fatalf("Failed to load agent config: %v", err)
+	}
+
+	tree, err := analyzer.BuildTree(tmpDir, agentConfig)
+	if err != nil {
+		t.Fatalf("Failed to build tree: %v", err)
+	}
+
+	ctx := &AnalysisContext{
+		Tree:        tree,
+		AgentConfig: agentConfig,
+		RootPath:    tmpDir,
+	}
+
+	rule := &ImportDepthRule{}
+	issues, err := rule.Run(ctx)
+	if err != nil {
+		t.Fatalf("Run() returned error: %v", err)
+	}
+
+	if len(issues) != 1 {
+		t.Fatalf("Expected 1 issue, got %d: %+v", len(issues), issues)
+	}
+
+	issue := issues[0]
+	if issue.File != filepath.Join(tmpDir, "docs/level5.md") || issue.Line != 1 {
+		t.Errorf("Issue at %s:%d, want docs/level5.md:1", issue.File, issue.Line)
+	}
+	if !strings.Contains(issue.Message, "level6.md") || !strings.Contains(issue.Message, "6 imports deep") {
+		t.Errorf("Unexpected message: %s", issue.Message)
+	}
+	if !strings.Contains(issue.Message, "CLAUDE.md -> docs/level1.md") {
+		t.Errorf("Message should show the import chain: %s", issue.Message)
+	}
+
+	// The dropped file is still resolved so rules can report on it
+	node := tree.Nodes[filepath.Join(tmpDir, "docs/level5.md")]
+	if node == nil {
+		t.Fatal("docs/level5.md should be in the tree")
+	}
+	if ref := node.References[0]; !ref.Resolved || ref.Resolution != analyzer.ResolutionImportRelative {
+		t.Errorf("Import in docs/level5.md: resolved=%v resolution=%s", ref.Resolved, ref.Resolution)
+	}
+}
@@ -3,6 +3,7 @@ package rules
 import (
 	"strings"
 
+	"github.com/pthm/cclint/internal/analyzer"
 	"github.com/pthm/cclint/internal/parser"
 )
 
@@ -42,7 +43,7 @@ func (r *MissingContextRule) Run(ctx *AnalysisContext) ([]Issue, error) {
 
 		if hasImportantInstructions {
 			hasExamples := strings.Contains(contentLower, "example") ||
-				strings.Contains(content, "```") ||
+				hasCodeBlock(node) ||
 				strings.Contains(contentLower, "for instance")
 
 			if !hasExamples {
@@ -59,3 +60,13 @@ func (r *MissingContextRule) Run(ctx *AnalysisContext) ([]Issue, error) {
 
 	return issues, nil
 }
+
+// hasCodeBlock reports whether a markdown node contains a code block
+func hasCodeBlock(node *analyzer.ConfigNode) bool {
+	for _, block := range Blocks(node) {
+		if block.Kind == parser.BlockCode {
+			return true
+		}
+	}
+	return false
+}
@@ -32,8 +32,8 @@ func (r *MissingSkillRule) Run(ctx *AnalysisContext) ([]Issue, error) {
 	// Build set of available skill names from discovered skill scopes
 	availableSkills := make(map[string]bool)
 	for _, scope := range scopes {
-		if scope.Type == analyzer.ScopeTypeMain {
-			// Skills are children of main scope
+		if scope.Type == analyzer.ScopeTypeMain || scope.Type == analyzer.ScopeTypePlugin {
+			// Skills are children of main and plugin scopes
 			for _, child := range scope.Children {
 				if child.Type == analyzer.ScopeTypeSkill {
 					availableSkills[child.Name] = true
@@ -49,6 +49,10 @@ func (r *MissingSkillRule) Run(ctx *AnalysisContext) ([]Issue, error) {
 		}
 
 		for _, skill := range scope.DeclaredSkills {
+			// Plugin subagents may refer to their plugin's skills without the namespace
+			if scope.Plugin != "" && availableSkills[scope.Plugin+":"+skill] {
+				continue
+			}
 			if !availableSkills[skill] {
 				issues = append(issues, Issue{
 					Rule:     r.Name(),
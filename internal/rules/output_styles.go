@@ -0,0 +1,176 @@
+package rules
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+
+	"github.com/pthm/cclint/internal/analyzer"
+	"github.com/pthm/cclint/internal/parser"
+)
+
+// OutputStylesRule validates output styles (.claude/output-styles/*.md) and
+// checks that the outputStyle setting refers to a style that exists
+type OutputStylesRule struct{}
+
+func (r *OutputStylesRule) Name() string {
+	return "output-styles"
+}
+
+func (r *OutputStylesRule) Description() string {
+	return "Validates output style frontmatter and the outputStyle setting"
+}
+
+func (r *OutputStylesRule) Config() RuleConfig {
+	return RuleConfig{} // Output styles and settings are checked via scopes
+}
+
+// knownOutputStyleKeys are the documented output style frontmatter fields
+var knownOutputStyleKeys = map[string]bool{
+	"name":                     true,
+	"description":              true,
+	"keep-coding-instructions": true,
+}
+
+// builtinOutputStyles are the output styles shipped with Claude Code
+var builtinOutputStyles = []string{"default", "Explanatory", "Learning"}
+
+func (r *OutputStylesRule) Run(ctx *AnalysisContext) ([]Issue, error) {
+	var issues []Issue
+
+	scopes, err := ctx.Scopes()
+	if err != nil {
+		return nil, err
+	}
+
+	available := make(map[string]bool)
+	for _, name := range builtinOutputStyles {
+		available[strings.ToLower(name)] = true
+	}
+
+	for _, scope := range FlattenScopes(scopes) {
+		if scope.Type != analyzer.ScopeTypeOutputStyle {
+			continue
+		}
+		available[strings.ToLower(scope.Name)] = true
+		if node := ctx.Tree.Nodes[scope.Entrypoint]; node != nil {
+			issues = append(issues, r.checkStyle(node)...)
+		}
+	}
+
+	for _, name := range []string{"settings.json", "settings.local.json"} {
+		path := filepath.Join(ctx.RootPath, ".claude", name)
+		issues = append(issues, r.checkSetting(path, available)...)
+	}
+
+	return issues, nil
+}
+
+// checkStyle validates an output style's frontmatter and body
+func (r *OutputStylesRule) checkStyle(node *analyzer.ConfigNode) []Issue {
+	var issues []Issue
+
+	frontmatter, body := map[string]interface{}(nil), node.Content
+	fm := parser.ParseFrontmatterBlock(node.Content)
+	if fm != nil && fm.Err == nil {
+		frontmatter, body = fm.Fields, fm.Body
+	}
+
+	issue := func(subRule string, severity Severity, key, message string) {
+		line := 1
+		if fm != nil && key != "" {
+			line = fm.KeyLine(key)
+		}
+		issues = append(issues, Issue{
+			Rule:     r.Name() + "/" + subRule,
+			Severity: severity,
+			Message:  message,
+			File:     node.Path,
+			Line:     line,
+		})
+	}
+
+	if fm != nil && fm.Err != nil {
+		issues = append(issues, Issue{
+			Rule:     r.Name() + "/invalid-yaml",
+			Severity: Error,
+			Message:  fmt.Sprintf("Invalid frontmatter YAML: %v", fm.Err),
+			File:     node.Path,
+			Line:     fm.ErrLine,
+		})
+		return issues
+	}
+
+	if description, _ := frontmatter["description"].(string); strings.TrimSpace(description) == "" {
+		issue("missing-description", Warning, "", "Output style is missing 'description', which is shown in the /output-style menu")
+	}
+
+	if value, ok := frontmatter["name"]; ok {
+		if name, isString := value.(string); !isString || strings.TrimSpace(name) == "" {
+			issue("invalid-name", Error, "name", "Output style 'name' must be a non-empty string")
+		}
+	}
+
+	if value, ok := frontmatter["keep-coding-instructions"]; ok {
+		if _, isBool := value.(bool); !isBool {
+			issue("invalid-keep-coding-instructions", Error, "keep-coding-instructions",
+				fmt.Sprintf("Output style 'keep-coding-instructions' must be true or false, got '%v'", value))
+		}
+	}
+
+	keys := make([]string, 0, len(frontmatter))
+	for key := range frontmatter {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	for _, key := range keys {
+		if !knownOutputStyleKeys[key] {
+			issue("unknown-field", Suggestion, key, fmt.Sprintf("Unknown output style frontmatter field '%s'", key))
+		}
+	}
+
+	if strings.TrimSpace(string(body)) == "" {
+		issue("empty-style", Error, "", "Output style has no instructions; it would replace the system prompt with nothing")
+	}
+
+	return issues
+}
+
+// checkSetting verifies the outputStyle in a settings file names a known style
+func (r *OutputStylesRule) checkSetting(path string, available map[string]bool) []Issue {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil
+	}
+
+	var settings struct {
+		OutputStyle *string `json:"outputStyle"`
+	}
+	if err := json.Unmarshal(data, &settings); err != nil || settings.OutputStyle == nil {
+		return nil
+	}
+
+	style := *settings.OutputStyle
+	if available[strings.ToLower(style)] {
+		return nil
+	}
+
+	line := 1
+	if parsed, err := (&parser.JSONParser{}).Parse(path, data); err == nil {
+		line = parsed.LineOf("/outputStyle")
+	}
+
+	return []Issue{{
+		Rule:     r.Name() + "/unknown-style",
+		Severity: Error,
+		Message:  fmt.Sprintf("outputStyle '%s' does not match a built-in style or any file in .claude/output-styles/", style),
+		File:     path,
+		Line:     line,
+		Fix: &Fix{
+			Description: fmt.Sprintf("Create .claude/output-styles/%s.md or use one of: %s", style, strings.Join(builtinOutputStyles, ", ")),
+		},
+	}}
+}
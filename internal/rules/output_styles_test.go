@@ -0,0 +1,103 @@
+package rules
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/pthm/cclint/internal/agent"
+	"github.com/pthm/cclint/internal/analyzer"
+)
+
+func TestOutputStylesRule_Run(t *testing.T) {
+	tmpDir, err := os.MkdirTemp("", "output-styles-test-*")
+	if err != nil {
+		t.Fatalf("Failed to create temp dir: %v", err)
+	}
+	defer os.RemoveAll(tmpDir)
+
+	files := map[string]string{
+		"CLAUDE.md": "# Main Agent",
+		".claude/output-styles/terse.md": `---
+name: Terse
+description: Short answers only
+keep-coding-instructions: true
+---
+
+Answer in as few words as possible.
+`,
+		".claude/output-styles/broken.md": `---
+keep-coding-instructions: "yes"
+colour: blue
+---
+`,
+		".claude/settings.json": `{
+  "outputStyle": "Pirate"
+}`,
+		".claude/settings.local.json": `{
+  "outputStyle": "terse"
+}`,
+	}
+
+	for name, content := range files {
+		path := filepath.Join(tmpDir, name)
+		if err := os.MkdirAll(filepath.Dir(path), 0o755); err != nil {
+			t.Fatalf("Failed to create dir for %s: %v", name, err)
+		}
+		if err := os.WriteFile(path, []byte(content), 0o644); err != nil {
+			t.Fatalf("Failed to write file %s: %v", name, err)
+		}
+	}
+
+	agentConfig, err := agent.Load("claude-code")
+	if err != nil {
+		t.Fatalf("Failed to load agent config: %v", err)
+	}
+
+	tree, err := analyzer.BuildTree(tmpDir, agentConfig)
+	if err != nil {
+		t.Fatalf("Failed to build tree: %v", err)
+	}
+
+	ctx := &AnalysisContext{
+		Tree:        tree,
+		AgentConfig: agentConfig,
+		RootPath:    tmpDir,
+	}
+
+	rule := &OutputStylesRule{}
+	issues, err := rule.Run(ctx)
+	if err != nil {
+		t.Fatalf("Run() returned error: %v", err)
+	}
+
+	found := make(map[string]Issue)
+	for _, issue := range issues {
+		found[issue.Rule] = issue
+	}
+
+	for _, want := range []string{
+		"output-styles/missing-description",
+		"output-styles/invalid-keep-coding-instructions",
+		"output-styles/unknown-field",
+		"output-styles/empty-style",
+		"output-styles/unknown-style",
+	} {
+		if _, ok := found[want]; !ok {
+			t.Errorf("Expected issue %s, got %v", want, issues)
+		}
+	}
+
+	for _, issue := range issues {
+		if issue.File == filepath.Join(tmpDir, ".claude", "output-styles", "terse.md") {
+			t.Errorf("Valid output style should have no issues, got %v", issue)
+		}
+		if issue.File == filepath.Join(tmpDir, ".claude", "settings.local.json") {
+			t.Errorf("outputStyle 'terse' should match the Terse style, got %v", issue)
+		}
+	}
+
+	if issue := found["output-styles/invalid-keep-coding-instructions"]; issue.Line != 2 {
+		t.Errorf("invalid-keep-coding-instructions Line = %d, want 2", issue.Line)
+	}
+}
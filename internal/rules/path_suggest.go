@@ -0,0 +1,152 @@
+package rules
+
+import (
+	"io/fs"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/pthm/cclint/internal/analyzer"
+	"github.com/pthm/cclint/internal/git"
+)
+
+// pathSuggester finds the file a broken path most likely meant. The
+// repository's files and git rename history are loaded on first use and
+// shared across all lookups.
+type pathSuggester struct {
+	root     string
+	realRoot string // root with symlinks resolved, as git reports it
+	loaded   bool
+	files    []string          // Absolute paths of all files under root
+	renames  map[string]string // Old path -> new path, from git history
+}
+
+// newPathSuggester creates a suggester for files under root
+func newPathSuggester(root string) *pathSuggester {
+	return &pathSuggester{root: root}
+}
+
+// load indexes the repository files and rename history once
+func (s *pathSuggester) load() {
+	if s.loaded {
+		return
+	}
+	s.loaded = true
+
+	s.realRoot = s.root
+	if real, err := filepath.EvalSymlinks(s.root); err == nil {
+		s.realRoot = real
+	}
+
+	_ = filepath.WalkDir(s.root, func(path string, d fs.DirEntry, err error) error {
+		if err != nil {
+			return nil
+		}
+		if d.IsDir() {
+			if path != s.root && analyzer.SkipDirs[d.Name()] {
+				return filepath.SkipDir
+			}
+			return nil
+		}
+		s.files = append(s.files, path)
+		return nil
+	})
+
+	if renames, err := git.Renames(s.root); err == nil {
+		s.renames = renames
+	}
+}
+
+// pathSuggestion is the file a broken path most likely meant
+type pathSuggestion struct {
+	path   string
+	reason string
+	// certain is true when the match is unambiguous (a case-only mismatch, a
+	// git rename or the only file with that name), so the reference can be
+	// rewritten automatically. Similar spellings are only worth mentioning.
+	certain bool
+}
+
+// suggest returns the existing file that missing (an absolute path) most
+// likely refers to
+func (s *pathSuggester) suggest(missing string) (pathSuggestion, bool) {
+	s.load()
+
+	// Same path with different letter case (works on macOS, breaks on Linux)
+	for _, f := range s.files {
+		if strings.EqualFold(f, missing) {
+			return pathSuggestion{f, "the file name's case differs", true}, true
+		}
+	}
+
+	if moved, ok := s.followRenames(missing); ok {
+		return pathSuggestion{moved, "it was renamed in git history", true}, true
+	}
+
+	// A file with the same name elsewhere; prefer the closest path if several
+	base := filepath.Base(missing)
+	var sameName []string
+	for _, f := range s.files {
+		if filepath.Base(f) == base {
+			sameName = append(sameName, s.rel(f))
+		}
+	}
+	if len(sameName) == 1 {
+		return pathSuggestion{filepath.Join(s.root, sameName[0]), "a file with that name exists elsewhere", true}, true
+	}
+	if len(sameName) > 1 {
+		if best, ok := closestMatch(s.rel(missing), sameName); ok {
+			return pathSuggestion{filepath.Join(s.root, best), "a file with that name exists elsewhere", false}, true
+		}
+	}
+
+	// A similarly spelled path
+	candidates := make([]string, len(s.files))
+	for i, f := range s.files {
+		candidates[i] = s.rel(f)
+	}
+	if best, ok := closestMatch(s.rel(missing), candidates); ok {
+		return pathSuggestion{filepath.Join(s.root, best), "a similarly named file exists", false}, true
+	}
+
+	return pathSuggestion{}, false
+}
+
+// followRenames follows git renames from a missing path to a file that
+// still exists
+func (s *pathSuggester) followRenames(missing string) (string, bool) {
+	if len(s.renames) == 0 {
+		return "", false
+	}
+
+	rel := s.rel(missing)
+	if strings.HasPrefix(rel, "..") {
+		return "", false
+	}
+
+	current := filepath.Join(s.realRoot, rel)
+	seen := map[string]bool{current: true}
+	for {
+		next, ok := s.renames[current]
+		if !ok || seen[next] {
+			return "", false
+		}
+		seen[next] = true
+		current = next
+
+		// Translate back from git's real path to the project path
+		nextRel, err := filepath.Rel(s.realRoot, current)
+		if err != nil {
+			return "", false
+		}
+		candidate := filepath.Join(s.root, nextRel)
+		if _, err := os.Stat(candidate); err == nil {
+			return candidate, true
+		}
+	}
+}
+
+// rel returns path relative to the root
+func (s *pathSuggester) rel(path string) string {
+	return relPath(s.root, path)
+}
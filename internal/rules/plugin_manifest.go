@@ -0,0 +1,382 @@
+package rules
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"regexp"
+	"slices"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/pthm/cclint/internal/analyzer"
+	"github.com/pthm/cclint/internal/parser"
+)
+
+// PluginManifestRule validates Claude Code plugin manifests (plugin.json),
+// plugin hooks (hooks/hooks.json) and marketplace manifests (marketplace.json)
+type PluginManifestRule struct{}
+
+func (r *PluginManifestRule) Name() string {
+	return "plugin-manifest"
+}
+
+func (r *PluginManifestRule) Description() string {
+	return "Validates plugin.json, plugin hooks and marketplace.json manifests"
+}
+
+func (r *PluginManifestRule) Config() RuleConfig {
+	return RuleConfig{
+		FileCategories: []parser.FileCategory{
+			parser.FileCategoryConfig,
+		},
+	}
+}
+
+// kebabCasePattern matches plugin and marketplace names
+var kebabCasePattern = regexp.MustCompile(`^[a-z0-9]+(?:-[a-z0-9]+)*$`)
+
+// semverPattern matches semantic versions (e.g. 1.2.3, 1.0.0-beta.1)
+var semverPattern = regexp.MustCompile(`^\d+\.\d+\.\d+(?:-[0-9A-Za-z.-]+)?(?:\+[0-9A-Za-z.-]+)?$`)
+
+// knownPluginKeys are the documented top-level plugin.json fields
+var knownPluginKeys = map[string]bool{
+	"name":         true,
+	"version":      true,
+	"description":  true,
+	"author":       true,
+	"homepage":     true,
+	"repository":   true,
+	"license":      true,
+	"keywords":     true,
+	"commands":     true,
+	"agents":       true,
+	"skills":       true,
+	"hooks":        true,
+	"mcpServers":   true,
+	"outputStyles": true,
+	"lspServers":   true,
+}
+
+// knownHookEvents are the hook events Claude Code dispatches
+var knownHookEvents = map[string]bool{
+	"PreToolUse":        true,
+	"PostToolUse":       true,
+	"Notification":      true,
+	"UserPromptSubmit":  true,
+	"Stop":              true,
+	"SubagentStop":      true,
+	"PreCompact":        true,
+	"SessionStart":      true,
+	"SessionEnd":        true,
+	"PermissionRequest": true,
+}
+
+func (r *PluginManifestRule) Run(ctx *AnalysisContext) ([]Issue, error) {
+	var issues []Issue
+
+	for _, root := range ctx.Tree.PluginRoots(ctx.RootPath) {
+		issues = append(issues, r.checkPlugin(root)...)
+	}
+
+	for _, root := range ctx.Tree.Marketplaces(ctx.RootPath) {
+		issues = append(issues, r.checkMarketplace(root)...)
+	}
+
+	return issues, nil
+}
+
+func (r *PluginManifestRule) checkPlugin(root string) []Issue {
+	var issues []Issue
+
+	path := analyzer.PluginManifestPath(root)
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil
+	}
+
+	var manifest map[string]interface{}
+	if err := json.Unmarshal(data, &manifest); err != nil {
+		return []Issue{r.invalidJSON(path, data, err)}
+	}
+	parsed, _ := (&parser.JSONParser{}).Parse(path, data)
+
+	issue := func(subRule string, severity Severity, pointer, message string) {
+		issues = append(issues, Issue{
+			Rule:     r.Name() + "/" + subRule,
+			Severity: severity,
+			Message:  message,
+			File:     path,
+			Line:     parsed.LineOf(pointer),
+		})
+	}
+
+	name, _ := manifest["name"].(string)
+	switch {
+	case name == "":
+		issue("missing-name", Error, "", "Plugin manifest is missing required field 'name'")
+	case !kebabCasePattern.MatchString(name):
+		issue("invalid-name", Warning, "/name", fmt.Sprintf("Plugin name '%s' should be kebab-case (it is used as the command namespace)", name))
+	}
+
+	if version, ok := manifest["version"].(string); ok && !semverPattern.MatchString(version) {
+		issue("invalid-version", Warning, "/version", fmt.Sprintf("Plugin version '%s' is not a semantic version", version))
+	}
+
+	if author, ok := manifest["author"]; ok {
+		if obj, isObj := author.(map[string]interface{}); !isObj || obj["name"] == nil {
+			issue("invalid-author", Warning, "/author", "Plugin 'author' should be an object with a 'name' field")
+		}
+	}
+
+	keys := make([]string, 0, len(manifest))
+	for key := range manifest {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	for _, key := range keys {
+		if !knownPluginKeys[key] {
+			issue("unknown-field", Suggestion, parser.JSONPointer(key), fmt.Sprintf("Unknown plugin manifest field '%s'", key))
+		}
+	}
+
+	// Custom component paths must be relative to the plugin root and exist
+	for _, key := range []string{"commands", "agents", "skills", "hooks", "mcpServers", "outputStyles"} {
+		for _, p := range manifestPaths(manifest[key]) {
+			if !strings.HasPrefix(p, "./") {
+				issue("invalid-path", Error, parser.JSONPointer(key), fmt.Sprintf("Plugin '%s' path '%s' must be relative to the plugin root and start with ./", key, p))
+				continue
+			}
+			if _, err := os.Stat(filepath.Join(root, p)); err != nil {
+				issue("missing-path", Error, parser.JSONPointer(key), fmt.Sprintf("Plugin '%s' path '%s' does not exist", key, p))
+			}
+		}
+	}
+
+	// Validate hooks config, once per file even if the manifest names the default
+	hooksPaths := []string{filepath.Join(root, "hooks", "hooks.json")}
+	for _, p := range manifestPaths(manifest["hooks"]) {
+		if path := filepath.Join(root, p); !slices.Contains(hooksPaths, path) {
+			hooksPaths = append(hooksPaths, path)
+		}
+	}
+	for _, hooksPath := range hooksPaths {
+		issues = append(issues, r.checkHooks(hooksPath)...)
+	}
+	if inline, ok := manifest["hooks"].(map[string]interface{}); ok {
+		issues = append(issues, r.checkHookEvents(path, parsed, inline)...)
+	}
+
+	return issues
+}
+
+func (r *PluginManifestRule) checkHooks(path string) []Issue {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil
+	}
+
+	var hooksFile map[string]interface{}
+	if err := json.Unmarshal(data, &hooksFile); err != nil {
+		return []Issue{r.invalidJSON(path, data, err)}
+	}
+	parsed, _ := (&parser.JSONParser{}).Parse(path, data)
+
+	hooks, ok := hooksFile["hooks"].(map[string]interface{})
+	if !ok {
+		return []Issue{{
+			Rule:     r.Name() + "/invalid-hooks",
+			Severity: Error,
+			Message:  "Hooks file must contain a top-level 'hooks' object keyed by event name",
+			File:     path,
+			Line:     1,
+		}}
+	}
+
+	return r.checkHookEvents(path, parsed, hooks)
+}
+
+// checkHookEvents validates a hooks object: event names and command entries.
+// The hooks object is at /hooks in both hooks.json and plugin.json.
+func (r *PluginManifestRule) checkHookEvents(path string, parsed *parser.ParsedFile, hooks map[string]interface{}) []Issue {
+	var issues []Issue
+
+	events := make([]string, 0, len(hooks))
+	for event := range hooks {
+		events = append(events, event)
+	}
+	sort.Strings(events)
+
+	for _, event := range events {
+		if !knownHookEvents[event] {
+			issues = append(issues, Issue{
+				Rule:     r.Name() + "/unknown-hook-event",
+				Severity: Warning,
+				Message:  fmt.Sprintf("Unknown hook event '%s'", event),
+				File:     path,
+				Line:     parsed.LineOf(parser.JSONPointer("hooks", event)),
+			})
+			continue
+		}
+
+		matchers, ok := hooks[event].([]interface{})
+		if !ok {
+			issues = append(issues, Issue{
+				Rule:     r.Name() + "/invalid-hooks",
+				Severity: Error,
+				Message:  fmt.Sprintf("Hook event '%s' must be an array of matcher entries", event),
+				File:     path,
+				Line:     parsed.LineOf(parser.JSONPointer("hooks", event)),
+			})
+			continue
+		}
+
+		for i, m := range matchers {
+			entryPointer := parser.JSONPointer("hooks", event, strconv.Itoa(i))
+			entry, _ := m.(map[string]interface{})
+			commands, _ := entry["hooks"].([]interface{})
+			if len(commands) == 0 {
+				issues = append(issues, Issue{
+					Rule:     r.Name() + "/invalid-hooks",
+					Severity: Error,
+					Message:  fmt.Sprintf("Hook entry for '%s' has no 'hooks' commands", event),
+					File:     path,
+					Line:     parsed.LineOf(entryPointer),
+				})
+				continue
+			}
+			for j, c := range commands {
+				cmd, _ := c.(map[string]interface{})
+				hookType, _ := cmd["type"].(string)
+				command, _ := cmd["command"].(string)
+				prompt, _ := cmd["prompt"].(string)
+				if (hookType == "command" && command == "") || (hookType == "prompt" && prompt == "") || hookType == "" {
+					issues = append(issues, Issue{
+						Rule:     r.Name() + "/invalid-hooks",
+						Severity: Error,
+						Message:  fmt.Sprintf("Hook for '%s' must have a 'type' and a matching 'command' or 'prompt'", event),
+						File:     path,
+						Line:     parsed.LineOf(entryPointer + "/hooks/" + strconv.Itoa(j)),
+					})
+				}
+			}
+		}
+	}
+
+	return issues
+}
+
+func (r *PluginManifestRule) checkMarketplace(root string) []Issue {
+	var issues []Issue
+
+	path := analyzer.MarketplaceManifestPath(root)
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil
+	}
+
+	var manifest struct {
+		Name  string `json:"name"`
+		Owner *struct {
+			Name string `json:"name"`
+		} `json:"owner"`
+		Plugins []map[string]interface{} `json:"plugins"`
+	}
+	if err := json.Unmarshal(data, &manifest); err != nil {
+		return []Issue{r.invalidJSON(path, data, err)}
+	}
+	parsed, _ := (&parser.JSONParser{}).Parse(path, data)
+
+	issue := func(subRule string, severity Severity, pointer, message string) {
+		issues = append(issues, Issue{
+			Rule:     r.Name() + "/" + subRule,
+			Severity: severity,
+			Message:  message,
+			File:     path,
+			Line:     parsed.LineOf(pointer),
+		})
+	}
+
+	if manifest.Name == "" {
+		issue("missing-name", Error, "", "Marketplace manifest is missing required field 'name'")
+	} else if !kebabCasePattern.MatchString(manifest.Name) {
+		issue("invalid-name", Warning, "/name", fmt.Sprintf("Marketplace name '%s' should be kebab-case", manifest.Name))
+	}
+
+	if manifest.Owner == nil || manifest.Owner.Name == "" {
+		issue("missing-owner", Error, "/owner", "Marketplace manifest requires an 'owner' object with a 'name'")
+	}
+
+	if len(manifest.Plugins) == 0 {
+		issue("no-plugins", Warning, "/plugins", "Marketplace does not list any plugins")
+	}
+
+	seen := make(map[string]bool)
+	for i, plugin := range manifest.Plugins {
+		entry := parser.JSONPointer("plugins", strconv.Itoa(i))
+		name, _ := plugin["name"].(string)
+		if name == "" {
+			issue("invalid-plugin-entry", Error, entry, fmt.Sprintf("Marketplace plugin entry %d is missing 'name'", i+1))
+			continue
+		}
+		if seen[name] {
+			issue("duplicate-plugin", Error, entry+"/name", fmt.Sprintf("Marketplace lists plugin '%s' more than once", name))
+		}
+		seen[name] = true
+
+		switch source := plugin["source"].(type) {
+		case nil:
+			issue("invalid-plugin-entry", Error, entry, fmt.Sprintf("Marketplace plugin '%s' is missing 'source'", name))
+		case string:
+			if !analyzer.IsRelativePluginSource(source) {
+				issue("invalid-plugin-entry", Error, entry+"/source", fmt.Sprintf("Marketplace plugin '%s' source '%s' must be a relative path starting with ./ or ../", name, source))
+				continue
+			}
+			pluginRoot := filepath.Join(root, source)
+			if _, err := os.Stat(analyzer.PluginManifestPath(pluginRoot)); err != nil {
+				issue("missing-plugin", Error, entry+"/source", fmt.Sprintf("Marketplace plugin '%s' source '%s' has no .claude-plugin/plugin.json", name, source))
+			}
+		case map[string]interface{}:
+			if source["source"] == nil {
+				issue("invalid-plugin-entry", Error, entry+"/source", fmt.Sprintf("Marketplace plugin '%s' source object is missing 'source' (e.g. \"github\")", name))
+			}
+		}
+	}
+
+	return issues
+}
+
+// invalidJSON builds an issue for a manifest that failed to parse
+func (r *PluginManifestRule) invalidJSON(path string, data []byte, err error) Issue {
+	line := 1
+	if syntaxErr, ok := err.(*json.SyntaxError); ok {
+		line = strings.Count(string(data[:syntaxErr.Offset]), "\n") + 1
+	}
+	return Issue{
+		Rule:     r.Name() + "/invalid-json",
+		Severity: Error,
+		Message:  fmt.Sprintf("Invalid JSON: %v", err),
+		File:     path,
+		Line:     line,
+	}
+}
+
+// manifestPaths returns path values from a manifest field that is either a
+// string or an array of strings
+func manifestPaths(value interface{}) []string {
+	switch v := value.(type) {
+	case string:
+		return []string{v}
+	case []interface{}:
+		var paths []string
+		for _, item := range v {
+			if s, ok := item.(string); ok {
+				paths = append(paths, s)
+			}
+		}
+		return paths
+	}
+	return nil
+}
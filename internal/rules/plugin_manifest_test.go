@@ -0,0 +1,139 @@
+package rules
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/pthm/cclint/internal/agent"
+	"github.com/pthm/cclint/internal/analyzer"
+)
+
+func TestPluginManifestRule_Run(t *testing.T) {
+	tmpDir, err := os.MkdirTemp("", "plugin-manifest-test-*")
+	if err != nil {
+		t.Fatalf("Failed to create temp dir: %v", err)
+	}
+	defer os.RemoveAll(tmpDir)
+
+	files := map[string]string{
+		"CLAUDE.md": "# Main Agent",
+		".claude-plugin/marketplace.json": `{
+  "name": "Internal Tools",
+  "plugins": [
+    {"name": "good", "source": "./plugins/good"},
+    {"name": "gone", "source": "./plugins/gone"}
+  ]
+}`,
+		"plugins/good/.claude-plugin/plugin.json": `{
+  "name": "Good_Plugin",
+  "version": "one",
+  "commands": "./custom",
+  "colour": "blue"
+}`,
+		"plugins/good/hooks/hooks.json": `{
+  "hooks": {
+    "BeforeEverything": [],
+    "PreToolUse": [{"matcher": "Bash", "hooks": [{"type": "command"}]}]
+  }
+}`,
+	}
+
+	for name, content := range files {
+		path := filepath.Join(tmpDir, name)
+		if err := os.MkdirAll(filepath.Dir(path), 0o755); err != nil {
+			t.Fatalf("Failed to create dir for %s: %v", name, err)
+		}
+		if err := os.WriteFile(path, []byte(content), 0o644); err != nil {
+			t.Fatalf("Failed to write file %s: %v", name, err)
+		}
+	}
+
+	agentConfig, err := agent.Load("claude-code")
+	if err != nil {
+		t.Fatalf("Failed to load agent config: %v", err)
+	}
+
+	tree, err := analyzer.BuildTree(tmpDir, agentConfig)
+	if err != nil {
+		t.Fatalf("Failed to build tree: %v", err)
+	}
+
+	ctx := &AnalysisContext{
+		Tree:        tree,
+		AgentConfig: agentConfig,
+		RootPath:    tmpDir,
+	}
+
+	rule := &PluginManifestRule{}
+	issues, err := rule.Run(ctx)
+	if err != nil {
+		t.Fatalf("Run() returned error: %v", err)
+	}
+
+	found := make(map[string]Issue)
+	for _, issue := range issues {
+		found[issue.Rule] = issue
+	}
+
+	for _, want := range []string{
+		"plugin-manifest/invalid-name",
+		"plugin-manifest/invalid-version",
+		"plugin-manifest/unknown-field",
+		"plugin-manifest/missing-path",
+		"plugin-manifest/unknown-hook-event",
+		"plugin-manifest/invalid-hooks",
+		"plugin-manifest/missing-owner",
+		"plugin-manifest/missing-plugin",
+	} {
+		if _, ok := found[want]; !ok {
+			t.Errorf("Expected issue %s, got %v", want, issues)
+		}
+	}
+
+	if issue := found["plugin-manifest/invalid-version"]; issue.Line != 3 {
+		t.Errorf("invalid-version Line = %d, want 3", issue.Line)
+	}
+}
+
+func TestPluginManifestRule_HooksCheckedOnce(t *testing.T) {
+	tmpDir := t.TempDir()
+
+	files := map[string]string{
+		".claude-plugin/plugin.json": `{"name": "hooked", "hooks": "./hooks/hooks.json"}`,
+		"hooks/hooks.json":           `{"hooks": {"BeforeEverything": []}}`,
+	}
+	for name, content := range files {
+		path := filepath.Join(tmpDir, name)
+		if err := os.MkdirAll(filepath.Dir(path), 0o755); err != nil {
+			t.Fatalf("Failed to create dir for %s: %v", name, err)
+		}
+		if err := os.WriteFile(path, []byte(content), 0o644); err != nil {
+			t.Fatalf("Failed to write file %s: %v", name, err)
+		}
+	}
+
+	agentConfig, err := agent.Load("claude-code")
+	if err != nil {
+		t.Fatalf("Failed to load agent config: %v", err)
+	}
+	tree, err := analyzer.BuildTree(tmpDir, agentConfig)
+	if err != nil {
+		t.Fatalf("Failed to build tree: %v", err)
+	}
+
+	issues, err := (&PluginManifestRule{}).Run(&AnalysisContext{Tree: tree, AgentConfig: agentConfig, RootPath: tmpDir})
+	if err != nil {
+		t.Fatalf("Run() returned error: %v", err)
+	}
+
+	count := 0
+	for _, issue := range issues {
+		if issue.Rule == "plugin-manifest/unknown-hook-event" {
+			count++
+		}
+	}
+	if count != 1 {
+		t.Errorf("unknown-hook-event reported %d times, want 1: %v", count, issues)
+	}
+}
@@ -0,0 +1,188 @@
+package rules
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"regexp"
+	"strings"
+
+	"github.com/pthm/cclint/internal/analyzer"
+	"github.com/pthm/cclint/internal/git"
+)
+
+// PortableRefsRule checks that file references work on every clone of the
+// repository: they must stay inside the project, avoid machine-specific home
+// directories and not point at git-ignored files
+type PortableRefsRule struct{}
+
+func (r *PortableRefsRule) Name() string {
+	return "portable-refs"
+}
+
+func (r *PortableRefsRule) Description() string {
+	return "Checks for file references that only work on one machine"
+}
+
+func (r *PortableRefsRule) Config() RuleConfig {
+	return RuleConfig{} // Applies to all file types
+}
+
+// homePathPattern matches absolute paths into a user's home directory
+// (/Users/alice/..., /home/alice/..., C:\Users\alice\...)
+var homePathPattern = regexp.MustCompile(`^(?:/Users/[^/]+|/home/[^/]+|[A-Za-z]:[\\/]Users[\\/][^\\/]+)(?:[\\/]|$)`)
+
+func (r *PortableRefsRule) Run(ctx *AnalysisContext) ([]Issue, error) {
+	var issues []Issue
+
+	realRoot, err := filepath.EvalSymlinks(ctx.RootPath)
+	if err != nil {
+		realRoot = ctx.RootPath
+	}
+
+	// Resolved references inside the project are checked against .gitignore
+	// in one batch once the other checks are done
+	var inside []analyzer.Reference
+
+	for _, node := range ctx.AllFiles() {
+		for _, ref := range node.References {
+			if ref.Type != analyzer.RefTypeFile || ref.Target == "" {
+				continue
+			}
+			// Paths in code examples are often illustrative rather than real
+			if ref.Origin == analyzer.RefOriginCode {
+				continue
+			}
+			// ~/ paths are the portable way to reference personal files
+			if strings.HasPrefix(strings.TrimPrefix(ref.Value, "@"), "~/") {
+				continue
+			}
+
+			if issue := r.checkRef(ctx, ref, realRoot); issue != nil {
+				issues = append(issues, *issue)
+				continue
+			}
+			if ref.Resolved {
+				inside = append(inside, ref)
+			}
+		}
+	}
+
+	issues = append(issues, r.checkIgnored(ctx, inside)...)
+
+	return issues, nil
+}
+
+// checkRef reports a reference that leaves the project or hardcodes a home
+// directory
+func (r *PortableRefsRule) checkRef(ctx *AnalysisContext, ref analyzer.Reference, realRoot string) *Issue {
+	value := strings.TrimPrefix(ref.Value, "@")
+
+	if home := homePrefix(value); home != "" {
+		// An absolute path to a file in this project: use a relative path
+		if isWithin(ctx.RootPath, value) {
+			base := ctx.RootPath
+			if ref.Import {
+				base = filepath.Dir(ref.Source.File) // Imports resolve relative to the importing file
+			}
+			issue := r.issue(ref, "home-path", fmt.Sprintf("Absolute path into the project only works on one machine: %s", ref.Value))
+			if rel, err := filepath.Rel(base, value); err == nil {
+				r.addPathFix(&issue, ref, filepath.ToSlash(rel))
+			}
+			return &issue
+		}
+
+		issue := r.issue(ref, "home-path", fmt.Sprintf("Reference to a home directory path only works on one machine: %s", ref.Value))
+		if ref.Import {
+			rest := filepath.ToSlash(strings.TrimPrefix(value, home))
+			r.addPathFix(&issue, ref, "~/"+strings.TrimPrefix(rest, "/"))
+		}
+		return &issue
+	}
+
+	if !isWithin(ctx.RootPath, ref.Target) {
+		issue := r.issue(ref, "outside-root", fmt.Sprintf("Reference points outside the project and won't exist in other clones: %s", ref.Value))
+		return &issue
+	}
+
+	if ref.Resolved {
+		if real, err := filepath.EvalSymlinks(ref.Target); err == nil && !isWithin(realRoot, real) {
+			issue := r.issue(ref, "symlink-outside-root", fmt.Sprintf("Reference is a symlink to a file outside the project: %s -> %s", ref.Value, real))
+			return &issue
+		}
+	}
+
+	return nil
+}
+
+// checkIgnored reports references to files that exist locally but are
+// ignored by git, so teammates won't have them
+func (r *PortableRefsRule) checkIgnored(ctx *AnalysisContext, refs []analyzer.Reference) []Issue {
+	var issues []Issue
+	if len(refs) == 0 {
+		return issues
+	}
+
+	paths := make([]string, len(refs))
+	for i, ref := range refs {
+		paths[i] = ref.Target
+	}
+	ignored, err := git.Ignored(ctx.RootPath, paths)
+	if err != nil {
+		return issues // Not a repository, or git is unavailable
+	}
+
+	for _, ref := range refs {
+		if ignored[filepath.Clean(ref.Target)] {
+			issues = append(issues, r.issue(ref, "gitignored", fmt.Sprintf("Referenced file is ignored by git and won't exist for teammates: %s", ref.Value)))
+		}
+	}
+	return issues
+}
+
+// issue builds an issue located at a reference
+func (r *PortableRefsRule) issue(ref analyzer.Reference, subRule, message string) Issue {
+	return Issue{
+		Rule:      r.Name() + "/" + subRule,
+		Severity:  Warning,
+		Message:   message,
+		File:      ref.Source.File,
+		Line:      ref.Source.Line,
+		Column:    ref.Source.Column,
+		EndColumn: ref.EndColumn,
+		Context:   ref.Context,
+	}
+}
+
+// addPathFix attaches a fix replacing the reference's path. Claude Code
+// expands ~/ in imports for every user.
+func (r *PortableRefsRule) addPathFix(issue *Issue, ref analyzer.Reference, replacement string) {
+	if ref.EndColumn <= ref.Source.Column {
+		return
+	}
+	issue.Fix = &Fix{
+		Description: fmt.Sprintf("Reference %s instead", replacement),
+		Edits:       []Edit{ReplaceText(ref.Source.File, ref.Source.Line, ref.Source.Column, ref.EndColumn, replacement)},
+	}
+}
+
+// homePrefix returns the home directory at the start of an absolute path,
+// or "" if the path is not in a home directory
+func homePrefix(path string) string {
+	if home, err := os.UserHomeDir(); err == nil && home != "/" && isWithin(home, path) && filepath.IsAbs(path) {
+		return home
+	}
+	if m := homePathPattern.FindString(path); m != "" {
+		return strings.TrimRight(m, `/\`)
+	}
+	return ""
+}
+
+// isWithin reports whether path is dir or inside it
+func isWithin(dir, path string) bool {
+	rel, err := filepath.Rel(dir, path)
+	if err != nil {
+		return false
+	}
+	return rel != ".." && !strings.HasPrefix(rel, ".."+string(filepath.Separator))
+}
@@ -0,0 +1,132 @@
+package rules
+
+import (
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/pthm/cclint/internal/agent"
+	"github.com/pthm/cclint/internal/analyzer"
+)
+
+func TestPortableRefsRule_Run(t *testing.T) {
+	if _, err := exec.LookPath("git"); err != nil {
+		t.Skip("git not installed")
+	}
+
+	parent := t.TempDir()
+	tmpDir := filepath.Join(parent, "project")
+
+	files := map[string]string{
+		"CLAUDE.md": `# Main Agent
+
+@../outside.md
+@/Users/alice/notes.md
+@docs/linked.md
+@local.md
+@docs/ok.md
+@~/.claude/personal.md
+
+` + "```\ncat /Users/alice/example.md\n```\n",
+		".gitignore":    "local.md\n",
+		"local.md":      "# Local notes",
+		"docs/ok.md":    "# OK",
+		"../outside.md": "# Outside",
+		"../shared.md":  "# Shared",
+	}
+
+	for name, content := range files {
+		path := filepath.Join(tmpDir, name)
+		if err := os.MkdirAll(filepath.Dir(path), 0o755); err != nil {
+			t.Fatalf("Failed to create dir for %s: %v", name, err)
+		}
+		if err := os.WriteFile(path, []byte(content), 0o644); err != nil {
+			t.Fatalf("Failed to write file %s: %v", name, err)
+		}
+	}
+	if err := os.Symlink(filepath.Join(parent, "shared.md"), filepath.Join(tmpDir, "docs/linked.md")); err != nil {
+		t.Skipf("symlinks not supported: %v", err)
+	}
+	if out, err := exec.Command("git", "-C", tmpDir, "init", "-q").CombinedOutput(); err != nil {
+		t.Fatalf("git init failed: %v: %s", err, out)
+	}
+
+	agentConfig, err := agent.Load("claude-code")
+	if err != nil {
+		t.Fatalf("Failed to load agent config: %v", err)
+	}
+
+	tree, err := analyzer.BuildTree(tmpDir, agentConfig)
+	if err != nil {
+		t.Fatalf("Failed to build tree: %v", err)
+	}
+
+	ctx := &AnalysisContext{
+		Tree:        tree,
+		AgentConfig: agentConfig,
+		RootPath:    tmpDir,
+	}
+
+	rule := &PortableRefsRule{}
+	issues, err := rule.Run(ctx)
+	if err != nil {
+		t.Fatalf("Run() returned error: %v", err)
+	}
+
+	byLine := make(map[int]Issue)
+	for _, issue := range issues {
+		if _, dup := byLine[issue.Line]; dup {
+			t.Errorf("Multiple issues on line %d", issue.Line)
+		}
+		byLine[issue.Line] = issue
+	}
+
+	want := map[int]string{
+		3: "portable-refs/outside-root",
+		4: "portable-refs/home-path",
+		5: "portable-refs/symlink-outside-root",
+		6: "portable-refs/gitignored",
+	}
+	for line, rule := range want {
+		issue, ok := byLine[line]
+		if !ok {
+			t.Errorf("Expected %s on line %d", rule, line)
+			continue
+		}
+		if issue.Rule != rule {
+			t.Errorf("Line %d: got %s, want %s", line, issue.Rule, rule)
+		}
+	}
+	if len(issues) != len(want) {
+		t.Errorf("Expected %d issues, got %d: %+v", len(want), len(issues), issues)
+	}
+
+	home := byLine[4]
+	if home.Fix == nil || len(home.Fix.Edits) != 1 {
+		t.Fatalf("Expected a fix for the home path, got %+v", home.Fix)
+	}
+	if got := editedLine(t, files["CLAUDE.md"], home.Fix.Edits[0]); got != "@~/notes.md" {
+		t.Errorf("Fix content = %q, want %q", got, "@~/notes.md")
+	}
+	if !strings.Contains(home.Message, "/Users/alice/notes.md") {
+		t.Errorf("Unexpected message: %s", home.Message)
+	}
+}
+
+func TestHomePrefix(t *testing.T) {
+	tests := map[string]string{
+		"/Users/alice/notes.md":     "/Users/alice",
+		"/home/bob/.claude/x.md":    "/home/bob",
+		`C:\Users\carol\notes.md`:   `C:\Users\carol`,
+		"/etc/hosts":                "",
+		"docs/Users/alice/notes.md": "",
+	}
+	t.Setenv("HOME", "/nonexistent-home")
+	for path, want := range tests {
+		if got := homePrefix(path); got != want {
+			t.Errorf("homePrefix(%q) = %q, want %q", path, got, want)
+		}
+	}
+}
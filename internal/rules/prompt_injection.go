@@ -0,0 +1,406 @@
+package rules
+
+import (
+	"fmt"
+	"regexp"
+	"strings"
+	"unicode"
+	"unicode/utf8"
+
+	"github.com/pthm/cclint/internal/analyzer"
+)
+
+// PromptInjectionRule checks for hidden or obfuscated content that can smuggle
+// instructions past a human reviewer: invisible Unicode, bidi overrides, tag
+// characters, homoglyphs, instructions tucked into HTML comments or collapsed
+// <details> blocks, and well-known prompt-injection phrasings.
+type PromptInjectionRule struct{}
+
+func (r *PromptInjectionRule) Name() string {
+	return "prompt-injection"
+}
+
+func (r *PromptInjectionRule) Description() string {
+	return "Checks for hidden Unicode, concealed instructions and prompt-injection phrasing"
+}
+
+func (r *PromptInjectionRule) Config() RuleConfig {
+	return RuleConfig{} // Applies to all file types
+}
+
+// invisibleKind classifies a hidden character
+type invisibleKind int
+
+const (
+	invisibleNone invisibleKind = iota
+	invisibleZeroWidth
+	invisibleBidi
+	invisibleTag
+)
+
+// classifyInvisible returns the kind of hidden character r is, if any
+func classifyInvisible(r rune) invisibleKind {
+	switch {
+	case r == '\u200b', r == '\u200c', r == '\u200d', r == '\u2060',
+		r == '\ufeff', r == '\u00ad', r == '\u180e', r == '\u2061',
+		r == '\u2062', r == '\u2063', r == '\u2064':
+		return invisibleZeroWidth
+	case r >= '\u202a' && r <= '\u202e', r >= '\u2066' && r <= '\u2069',
+		r == '\u200e', r == '\u200f', r == '\u061c':
+		return invisibleBidi
+	case r >= 0xE0000 && r <= 0xE007F:
+		return invisibleTag
+	}
+	return invisibleNone
+}
+
+// StripInvisible removes zero-width, bidi control and tag characters from s
+func StripInvisible(s string) string {
+	return strings.Map(func(r rune) rune {
+		if classifyInvisible(r) != invisibleNone {
+			return -1
+		}
+		return r
+	}, s)
+}
+
+// stripInvisibleOffsets is StripInvisible that also returns, for each byte
+// of the result, its byte offset in s, plus len(s) for the end
+func stripInvisibleOffsets(s string) (string, []int) {
+	var sb strings.Builder
+	offsets := make([]int, 0, len(s)+1)
+	for i, c := range s {
+		if classifyInvisible(c) != invisibleNone {
+			continue
+		}
+		_, size := utf8.DecodeRuneInString(s[i:])
+		sb.WriteString(s[i : i+size])
+		for b := 0; b < size; b++ {
+			offsets = append(offsets, i+b)
+		}
+	}
+	return sb.String(), append(offsets, len(s))
+}
+
+// injectionPatterns match phrasings commonly used to hijack an agent
+var injectionPatterns = []*regexp.Regexp{
+	regexp.MustCompile(`(?i)\b(ignore|disregard|forget|override)\s+(all\s+|any\s+)?(the\s+)?(previous|prior|above|earlier|preceding|other)\s+(instructions|rules|directions|guidance|prompts?)\b`),
+	regexp.MustCompile(`(?i)\byou\s+are\s+now\s+(a|an|in)\b`),
+	regexp.MustCompile(`(?i)\b(do\s+not|don't|never)\s+(tell|inform|mention\s+(this\s+)?to|reveal\s+(this\s+)?to|alert)\s+(the\s+)?user\b`),
+	regexp.MustCompile(`(?i)\b(reveal|print|output|repeat)\s+(your|the)\s+(system\s+prompt|hidden\s+instructions)\b`),
+	regexp.MustCompile(`(?i)\bnew\s+(system\s+)?instructions\s*:`),
+	regexp.MustCompile(`(?i)\b(exfiltrate|send|upload|post)\b.{0,40}\b(secrets?|credentials?|api\s+keys?|tokens?|\.env|ssh\s+keys?)\b.{0,40}\b(to|at)\s+https?://`),
+	regexp.MustCompile(`(?i)<\s*/?\s*(system|im_start|im_end)\s*>`),
+}
+
+var (
+	htmlCommentPattern = regexp.MustCompile(`(?s)<!--(.*?)-->`)
+	detailsPattern     = regexp.MustCompile(`(?is)<details(\s[^>]*)?>(.*?)</details>`)
+	detailsOpenAttr    = regexp.MustCompile(`(?i)\bopen\b`)
+
+	// addressesAgentPattern matches text that speaks directly to the agent
+	addressesAgentPattern = regexp.MustCompile(`(?i)\b(you|your|claude|assistant|agent|AI)\b`)
+)
+
+func (r *PromptInjectionRule) Run(ctx *AnalysisContext) ([]Issue, error) {
+	var issues []Issue
+
+	for _, node := range ctx.AllFiles() {
+		if len(node.Content) == 0 {
+			continue
+		}
+
+		issues = append(issues, r.checkInvisible(node)...)
+		issues = append(issues, r.checkConfusables(node)...)
+		issues = append(issues, r.checkInjectionPhrases(node)...)
+		issues = append(issues, r.checkConcealedBlocks(node, ctx)...)
+	}
+
+	return issues, nil
+}
+
+// checkInvisible reports lines containing invisible characters, with a fix
+// that strips them
+func (r *PromptInjectionRule) checkInvisible(node *analyzer.ConfigNode) []Issue {
+	var issues []Issue
+
+	lines := strings.Split(string(node.Content), "\n")
+	for lineNum, line := range lines {
+		counts := make(map[invisibleKind]int)
+		firstColumn := 0
+		for i, c := range line {
+			// A byte-order mark at the very start of a file is harmless
+			if lineNum == 0 && i == 0 && c == '\ufeff' {
+				continue
+			}
+			kind := classifyInvisible(c)
+			if kind == invisibleNone {
+				continue
+			}
+			counts[kind]++
+			if firstColumn == 0 {
+				firstColumn = i + 1
+			}
+		}
+		if len(counts) == 0 {
+			continue
+		}
+
+		subRule, severity, what := "invisible-unicode", Warning, "zero-width"
+		total := counts[invisibleZeroWidth]
+		if counts[invisibleBidi] > 0 {
+			subRule, severity, what = "bidi-control", Error, "bidirectional override"
+			total = counts[invisibleBidi]
+		}
+		if counts[invisibleTag] > 0 {
+			subRule, severity, what = "tag-characters", Error, "Unicode tag"
+			total = counts[invisibleTag]
+		}
+
+		stripped := StripInvisible(line)
+		if lineNum == 0 && strings.HasPrefix(line, "\ufeff") {
+			stripped = "\ufeff" + stripped
+		}
+
+		issues = append(issues, Issue{
+			Rule:     r.Name() + "/" + subRule,
+			Severity: severity,
+			Message:  fmt.Sprintf("Line contains %d hidden %s character(s) that are invisible when rendered", total, what),
+			File:     node.Path,
+			Line:     lineNum + 1,
+			Column:   firstColumn,
+			Context:  visualizeInvisible(line),
+			Fix: &Fix{
+				Description: "Strip invisible Unicode characters",
+				Edits: []Edit{
+					{
+						File:       node.Path,
+						StartLine:  lineNum + 1,
+						EndLine:    lineNum + 1,
+						NewContent: stripped,
+					},
+				},
+			},
+		})
+	}
+
+	return issues
+}
+
+// checkConfusables reports words that mix Latin letters with look-alike
+// Cyrillic or Greek letters
+func (r *PromptInjectionRule) checkConfusables(node *analyzer.ConfigNode) []Issue {
+	var issues []Issue
+
+	lines := strings.Split(string(node.Content), "\n")
+	for lineNum, line := range lines {
+		words := strings.FieldsFunc(line, func(c rune) bool {
+			return !unicode.IsLetter(c) && !unicode.IsDigit(c)
+		})
+		for _, word := range words {
+			if !isMixedScript(word) {
+				continue
+			}
+			column := strings.Index(line, word) + 1
+			issues = append(issues, Issue{
+				Rule:      r.Name() + "/confusable",
+				Severity:  Warning,
+				Message:   fmt.Sprintf("Word %q mixes Latin with look-alike Cyrillic or Greek letters", word),
+				File:      node.Path,
+				Line:      lineNum + 1,
+				Column:    column,
+				EndColumn: column + len(word),
+				Context:   strings.TrimSpace(line),
+			})
+		}
+	}
+
+	return issues
+}
+
+// isMixedScript reports whether word contains both Latin and Cyrillic/Greek letters
+func isMixedScript(word string) bool {
+	hasLatin, hasOther := false, false
+	for _, c := range word {
+		switch {
+		case unicode.In(c, unicode.Latin):
+			hasLatin = true
+		case unicode.In(c, unicode.Cyrillic, unicode.Greek):
+			hasOther = true
+		}
+	}
+	return hasLatin && hasOther
+}
+
+// checkInjectionPhrases reports known prompt-injection phrasings
+func (r *PromptInjectionRule) checkInjectionPhrases(node *analyzer.ConfigNode) []Issue {
+	var issues []Issue
+
+	lines := strings.Split(string(node.Content), "\n")
+	for lineNum, line := range lines {
+		// Match on the visible text, then map the match back to the line
+		normalized, offsets := stripInvisibleOffsets(line)
+		for _, pattern := range injectionPatterns {
+			loc := pattern.FindStringIndex(normalized)
+			if loc == nil {
+				continue
+			}
+			issues = append(issues, Issue{
+				Rule:      r.Name() + "/injection-phrase",
+				Severity:  Warning,
+				Message:   fmt.Sprintf("Possible prompt-injection phrasing: %q", normalized[loc[0]:loc[1]]),
+				File:      node.Path,
+				Line:      lineNum + 1,
+				Column:    offsets[loc[0]] + 1,
+				EndColumn: offsets[loc[1]-1] + 2,
+				Context:   strings.TrimSpace(normalized),
+			})
+			break
+		}
+	}
+
+	return issues
+}
+
+// checkConcealedBlocks reports instructions hidden in HTML comments or in
+// collapsed <details> blocks, which are not visible in rendered markdown
+func (r *PromptInjectionRule) checkConcealedBlocks(node *analyzer.ConfigNode, ctx *AnalysisContext) []Issue {
+	var issues []Issue
+	content := string(node.Content)
+
+	for _, match := range htmlCommentPattern.FindAllStringSubmatchIndex(content, -1) {
+		body := content[match[2]:match[3]]
+		if !r.looksLikeInstruction(body, ctx) {
+			continue
+		}
+		issues = append(issues, Issue{
+			Rule:     r.Name() + "/html-comment",
+			Severity: Warning,
+			Message:  "HTML comment contains instructions that are hidden when the markdown is rendered",
+			File:     node.Path,
+			Line:     strings.Count(content[:match[0]], "\n") + 1,
+			EndLine:  strings.Count(content[:match[1]], "\n") + 1,
+			Context:  truncateContext(strings.TrimSpace(body)),
+		})
+	}
+
+	for _, match := range detailsPattern.FindAllStringSubmatchIndex(content, -1) {
+		if match[2] >= 0 && detailsOpenAttr.MatchString(content[match[2]:match[3]]) {
+			continue
+		}
+		body := content[match[4]:match[5]]
+		if !r.looksLikeInstruction(body, ctx) {
+			continue
+		}
+		issues = append(issues, Issue{
+			Rule:     r.Name() + "/collapsed-details",
+			Severity: Info,
+			Message:  "Collapsed <details> block contains instructions that reviewers may not expand",
+			File:     node.Path,
+			Line:     strings.Count(content[:match[0]], "\n") + 1,
+			EndLine:  strings.Count(content[:match[1]], "\n") + 1,
+			Context:  truncateContext(strings.TrimSpace(body)),
+		})
+	}
+
+	return issues
+}
+
+// looksLikeInstruction reports whether hidden text reads like a directive
+// aimed at the agent rather than an ordinary authoring note
+func (r *PromptInjectionRule) looksLikeInstruction(text string, ctx *AnalysisContext) bool {
+	for _, pattern := range injectionPatterns {
+		if pattern.MatchString(text) {
+			return true
+		}
+	}
+
+	if !addressesAgentPattern.MatchString(text) {
+		return false
+	}
+
+	upper := strings.ToUpper(text)
+	for _, marker := range ctx.AgentConfig.Markers.HighPriority {
+		if containsWord(upper, strings.ToUpper(marker)) {
+			return true
+		}
+	}
+	return false
+}
+
+// containsWord reports whether word appears in s on word boundaries, as
+// `\b` would match them
+func containsWord(s, word string) bool {
+	if word == "" {
+		return false
+	}
+	for from := 0; from < len(s); {
+		i := strings.Index(s[from:], word)
+		if i < 0 {
+			return false
+		}
+		start, end := from+i, from+i+len(word)
+		if wordBoundary(s, start) && wordBoundary(s, end) {
+			return true
+		}
+		from = start + 1
+	}
+	return false
+}
+
+// lastWordEnd returns the offset just past the last occurrence of word in s
+// on word boundaries, or -1 if there is none
+func lastWordEnd(s, word string) int {
+	if word == "" {
+		return -1
+	}
+	for end := len(s); end > 0; {
+		i := strings.LastIndex(s[:end], word)
+		if i < 0 {
+			return -1
+		}
+		if wordBoundary(s, i) && wordBoundary(s, i+len(word)) {
+			return i + len(word)
+		}
+		end = i + len(word) - 1
+	}
+	return -1
+}
+
+// wordBoundary reports whether offset i of s is a word boundary
+func wordBoundary(s string, i int) bool {
+	before := i > 0 && isWordByte(s[i-1])
+	after := i < len(s) && isWordByte(s[i])
+	return before != after
+}
+
+// isWordByte reports whether b is an ASCII word character
+func isWordByte(b byte) bool {
+	return b == '_' || b >= '0' && b <= '9' || b >= 'a' && b <= 'z' || b >= 'A' && b <= 'Z'
+}
+
+// visualizeInvisible renders hidden characters as <U+XXXX> so they can be seen
+func visualizeInvisible(line string) string {
+	var sb strings.Builder
+	for _, c := range line {
+		if classifyInvisible(c) != invisibleNone {
+			sb.WriteString(fmt.Sprintf("<U+%04X>", c))
+			continue
+		}
+		sb.WriteRune(c)
+	}
+	return truncateContext(strings.TrimSpace(sb.String()))
+}
+
+// truncateContext shortens long context strings for display, cutting on a
+// rune boundary
+func truncateContext(s string) string {
+	if len(s) <= 160 {
+		return s
+	}
+	cut := 160
+	for cut > 0 && !utf8.RuneStart(s[cut]) {
+		cut--
+	}
+	return s[:cut] + "..."
+}
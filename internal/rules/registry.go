@@ -1,14 +1,28 @@
 package rules
 
+import "strings"
+
+// Category groups rules by what they check
+type Category string
+
+const (
+	CategoryStructural Category = "structural"
+	CategorySecurity   Category = "security"
+	CategoryContent    Category = "content"
+	CategoryAI         Category = "ai"
+)
+
 // Registry holds all registered rules
 type Registry struct {
-	rules []Rule
+	rules      []Rule
+	categories map[string]Category
 }
 
 // NewRegistry creates a new rule registry
 func NewRegistry() *Registry {
 	return &Registry{
-		rules: make([]Rule, 0),
+		rules:      make([]Rule, 0),
+		categories: make(map[string]Category),
 	}
 }
 
@@ -17,6 +31,25 @@ func (r *Registry) Register(rule Rule) {
 	r.rules = append(r.rules, rule)
 }
 
+// RegisterIn adds a rule to the registry under a category
+func (r *Registry) RegisterIn(category Category, rule Rule) {
+	r.Register(rule)
+	r.categories[rule.Name()] = category
+}
+
+// Category returns the category of a rule or sub-rule (rule/sub-rule).
+// Uncategorized AI rules are CategoryAI; other uncategorized rules are "".
+func (r *Registry) Category(name string) Category {
+	parent, _, _ := strings.Cut(name, "/")
+	if category, ok := r.categories[parent]; ok {
+		return category
+	}
+	if rule := r.Get(parent); rule != nil && rule.Config().RequiresAI {
+		return CategoryAI
+	}
+	return ""
+}
+
 // Rules returns all registered rules, optionally filtering by AI requirement.
 // If includeAI is false, rules with RequiresAI=true are excluded.
 func (r *Registry) Rules(includeAI bool) []Rule {
@@ -48,34 +81,45 @@ func DefaultRegistry() *Registry {
 	r := NewRegistry()
 
 	// Register structural rules
-	r.Register(&BrokenRefsRule{})
-	r.Register(&CircularRefsRule{})
-	r.Register(&LongDocumentRule{})
-	r.Register(&MissingEntrypointRule{})
-	r.Register(&BroadPermissionsRule{})
-	r.Register(&DuplicateInstructionsRule{})
-	r.Register(&MissingToolRule{})
-	r.Register(&MissingSkillRule{})
+	r.RegisterIn(CategoryStructural, &BrokenRefsRule{})
+	r.RegisterIn(CategoryStructural, &BrokenLinksRule{})
+	r.RegisterIn(CategoryStructural, &CircularRefsRule{})
+	r.RegisterIn(CategoryStructural, &ImportDepthRule{})
+	r.RegisterIn(CategoryStructural, &PortableRefsRule{})
+	r.RegisterIn(CategoryStructural, &LongDocumentRule{})
+	r.RegisterIn(CategoryStructural, &MissingEntrypointRule{})
+	r.RegisterIn(CategoryStructural, &BroadPermissionsRule{})
+	r.RegisterIn(CategoryStructural, &DuplicateInstructionsRule{})
+	r.RegisterIn(CategoryStructural, &MissingToolRule{})
+	r.RegisterIn(CategoryStructural, &MissingSkillRule{})
+	r.RegisterIn(CategoryStructural, &PluginManifestRule{})
+	r.RegisterIn(CategoryStructural, &OutputStylesRule{})
+	r.RegisterIn(CategoryStructural, &FrontmatterRule{})
+
+	// Register security rules
+	r.RegisterIn(CategorySecurity, &SecretsRule{})
+	r.RegisterIn(CategorySecurity, &PromptInjectionRule{})
+	r.RegisterIn(CategorySecurity, &RiskyInstructionsRule{})
 
 	// Register content quality rules
-	r.Register(&VagueInstructionsRule{})
-	r.Register(&ContradictionsRule{})
-	r.Register(&MissingContextRule{})
-	r.Register(&VerbosityRule{})
+	r.RegisterIn(CategoryContent, &VagueInstructionsRule{})
+	r.RegisterIn(CategoryContent, &ContradictionsRule{})
+	r.RegisterIn(CategoryContent, &MissingContextRule{})
+	r.RegisterIn(CategoryContent, &VerbosityRule{})
 
 	// Register AI rules (requires --deep flag)
 	// These rules analyze configurations per-scope (main agent and each subagent separately)
 	if rule := NewLLMDuplicatesRule(); rule != nil {
-		r.Register(rule)
+		r.RegisterIn(CategoryAI, rule)
 	}
 	if rule := NewLLMContradictionsRule(); rule != nil {
-		r.Register(rule)
+		r.RegisterIn(CategoryAI, rule)
 	}
 	if rule := NewLLMClarityRule(); rule != nil {
-		r.Register(rule)
+		r.RegisterIn(CategoryAI, rule)
 	}
 	if rule := NewLLMActionabilityRule(); rule != nil {
-		r.Register(rule)
+		r.RegisterIn(CategoryAI, rule)
 	}
 
 	return r
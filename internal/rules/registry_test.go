@@ -0,0 +1,21 @@
+package rules
+
+import "testing"
+
+func TestRegistryCategory(t *testing.T) {
+	r := NewRegistry()
+	r.RegisterIn(CategorySecurity, &SecretsRule{})
+	r.Register(&VerbosityRule{})
+
+	tests := map[string]Category{
+		"secrets":                  CategorySecurity,
+		"secrets/aws-key":          CategorySecurity,
+		"verbosity/long-sentences": "",
+		"unknown":                  "",
+	}
+	for name, want := range tests {
+		if got := r.Category(name); got != want {
+			t.Errorf("Category(%s) = %q, want %q", name, got, want)
+		}
+	}
+}
@@ -0,0 +1,281 @@
+package rules
+
+import (
+	"embed"
+	"fmt"
+	"regexp"
+	"strings"
+
+	"github.com/pthm/cclint/internal/analyzer"
+	"github.com/pthm/cclint/internal/config"
+	"github.com/pthm/cclint/internal/parser"
+	"gopkg.in/yaml.v3"
+)
+
+//go:embed policies/risky-instructions.yaml
+var policiesFS embed.FS
+
+// RiskyInstructionsRule checks instructions for destructive or
+// safety-bypassing guidance such as "always use --no-verify" or "run with sudo".
+// Patterns are data-driven: built-in packs are embedded and projects can add
+// or disable patterns in .cclint.yaml.
+type RiskyInstructionsRule struct{}
+
+func (r *RiskyInstructionsRule) Name() string {
+	return "risky-instructions"
+}
+
+func (r *RiskyInstructionsRule) Description() string {
+	return "Checks for instructions that encourage destructive or safety-bypassing actions"
+}
+
+func (r *RiskyInstructionsRule) Config() RuleConfig {
+	return RuleConfig{} // Applies to markdown in every scope
+}
+
+// riskyPattern is a compiled policy pattern
+type riskyPattern struct {
+	pack      string
+	id        string
+	regex     *regexp.Regexp
+	severity  Severity
+	rationale string
+}
+
+// clauseBoundary splits a line into clauses so negation only applies locally
+var clauseBoundary = regexp.MustCompile(`[.;!?]\s|,\s+(?:but|then|and)\s`)
+
+// maxNegationGap is how many words may separate a prohibitive marker from
+// the instruction it negates, as in "Do not ever commit with --no-verify"
+const maxNegationGap = 4
+
+func (r *RiskyInstructionsRule) Run(ctx *AnalysisContext) ([]Issue, error) {
+	patterns, err := r.loadPatterns(ctx.Project)
+	if err != nil {
+		return nil, err
+	}
+
+	scopes, err := ctx.Scopes()
+	if err != nil {
+		return nil, err
+	}
+
+	var issues []Issue
+	seen := make(map[string]bool)
+
+	// Commands and skills are nested scopes, so walk them as well
+	for _, scope := range FlattenScopes(scopes) {
+		for _, node := range scope.Nodes {
+			if node.Parsed == nil || node.Parsed.FileType != parser.FileTypeMarkdown {
+				continue
+			}
+
+			for _, issue := range r.checkFile(ctx, node, patterns) {
+				// A file shared by several scopes is only reported once
+				key := fmt.Sprintf("%s:%d:%s", issue.File, issue.Line, issue.Rule)
+				if seen[key] {
+					continue
+				}
+				seen[key] = true
+
+				issue.Message += fmt.Sprintf(" (in %s)", ScopeContextDescription(scope))
+				issues = append(issues, issue)
+			}
+		}
+	}
+
+	return issues, nil
+}
+
+func (r *RiskyInstructionsRule) checkFile(ctx *AnalysisContext, node *analyzer.ConfigNode, patterns []riskyPattern) []Issue {
+	var issues []Issue
+
+	fileLines := strings.Split(string(node.Content), "\n")
+	heading := ""
+	leadIn := false // a prohibitive "Never do the following:" paragraph before a list
+
+	// prohibitedItems[d] is true if the list item at depth d+1 (or an ancestor) is prohibitive
+	var prohibitedItems []bool
+
+	for _, block := range Blocks(node) {
+		switch {
+		case block.Kind == parser.BlockHeading:
+			heading, leadIn, prohibitedItems = block.Text, false, nil
+			continue
+		case block.Kind == parser.BlockListItem:
+			if len(prohibitedItems) >= block.ListDepth {
+				prohibitedItems = prohibitedItems[:block.ListDepth-1]
+			}
+			inherited := len(prohibitedItems) > 0 && prohibitedItems[len(prohibitedItems)-1]
+			prohibitedItems = append(prohibitedItems, inherited || r.isProhibited(ctx, block.Text))
+		case block.ListDepth == 0:
+			prohibitedItems = nil
+		}
+
+		// List items under a "Never do" style heading, lead-in or parent item inherit its negation
+		listNegated := false
+		if block.ListDepth > 0 {
+			listNegated = r.isProhibited(ctx, heading) || leadIn
+			// A list item inherits from its parent item; other blocks in an item from the item itself
+			parent := len(prohibitedItems) - 1
+			if block.Kind == parser.BlockListItem {
+				parent--
+			}
+			if parent >= 0 && parent < len(prohibitedItems) && prohibitedItems[parent] {
+				listNegated = true
+			}
+		}
+
+		for i, line := range block.Lines() {
+			lineNum := block.StartLine + i
+			for _, p := range patterns {
+				loc := p.regex.FindStringIndex(line)
+				if loc == nil {
+					continue
+				}
+
+				if listNegated || r.isNegated(ctx, line, loc[0]) {
+					continue
+				}
+				clause := clauseBefore(line, loc[0])
+
+				severity := p.severity
+				if r.isEmphasized(ctx, clause) && severity < Error {
+					severity++
+				}
+
+				// Block text omits list markers and indentation, so locate it in the file line
+				column, context := loc[0]+1, strings.TrimSpace(line)
+				if lineNum <= len(fileLines) {
+					fileLine := fileLines[lineNum-1]
+					if idx := strings.Index(fileLine, line); idx >= 0 {
+						column = idx + loc[0] + 1
+					}
+					context = strings.TrimSpace(fileLine)
+				}
+
+				issues = append(issues, Issue{
+					Rule:      r.Name() + "/" + p.id,
+					Severity:  severity,
+					Message:   fmt.Sprintf("Risky instruction %q: %s", line[loc[0]:loc[1]], p.rationale),
+					File:      node.Path,
+					Line:      lineNum,
+					Column:    column,
+					EndColumn: column + loc[1] - loc[0],
+					Context:   context,
+				})
+			}
+		}
+
+		if block.ListDepth == 0 {
+			leadIn = block.Kind.IsProse() && strings.HasSuffix(strings.TrimSpace(block.Text), ":") && r.isProhibited(ctx, block.Text)
+		}
+	}
+
+	return issues
+}
+
+// clauseBefore returns the text of the clause leading up to offset
+func clauseBefore(line string, offset int) string {
+	prefix := line[:offset]
+	bounds := clauseBoundary.FindAllStringIndex(prefix, -1)
+	if len(bounds) > 0 {
+		prefix = prefix[bounds[len(bounds)-1][1]:]
+	}
+	return prefix
+}
+
+// isProhibited reports whether text contains a prohibitive marker (NEVER,
+// DO NOT, ...). It is used for headings, lead-ins and list items, whose
+// negation carries over to the items below them.
+func (r *RiskyInstructionsRule) isProhibited(ctx *AnalysisContext, text string) bool {
+	if text == "" {
+		return false
+	}
+	upper := strings.ToUpper(strings.ReplaceAll(text, "’", "'"))
+	for _, marker := range ctx.AgentConfig.Markers.Prohibitive {
+		if containsWord(upper, strings.ToUpper(marker)) {
+			return true
+		}
+	}
+	return false
+}
+
+// isNegated reports whether the match at offset in line is governed by a
+// prohibitive marker: one at most maxNegationGap words before it in the same
+// clause, with no comma or colon between them. A marker elsewhere in the
+// clause, as in "If the hook does not pass, commit with --no-verify", does
+// not negate the match.
+func (r *RiskyInstructionsRule) isNegated(ctx *AnalysisContext, line string, offset int) bool {
+	clause := clauseBefore(line, offset)
+	if i := strings.LastIndexAny(clause, ",:;()"); i >= 0 {
+		clause = clause[i+1:]
+	}
+	upper := strings.ToUpper(strings.ReplaceAll(clause, "’", "'"))
+	for _, marker := range ctx.AgentConfig.Markers.Prohibitive {
+		end := lastWordEnd(upper, strings.ToUpper(marker))
+		if end >= 0 && len(strings.Fields(upper[end:])) <= maxNegationGap {
+			return true
+		}
+	}
+	return false
+}
+
+// isEmphasized reports whether text contains a high-priority marker (ALWAYS, MUST, ...)
+func (r *RiskyInstructionsRule) isEmphasized(ctx *AnalysisContext, text string) bool {
+	upper := strings.ToUpper(text)
+	for _, marker := range ctx.AgentConfig.Markers.HighPriority {
+		if containsWord(upper, strings.ToUpper(marker)) {
+			return true
+		}
+	}
+	return false
+}
+
+// loadPatterns combines the built-in packs with project packs, minus any
+// patterns or packs disabled by the project
+func (r *RiskyInstructionsRule) loadPatterns(project *config.Project) ([]riskyPattern, error) {
+	data, err := policiesFS.ReadFile("policies/risky-instructions.yaml")
+	if err != nil {
+		return nil, err
+	}
+
+	var builtin config.RiskyInstructions
+	if err := yaml.Unmarshal(data, &builtin); err != nil {
+		return nil, fmt.Errorf("invalid built-in risky-instructions packs: %w", err)
+	}
+
+	packs := builtin.Packs
+	disabled := make(map[string]bool)
+	if project != nil {
+		packs = append(packs, project.RiskyInstructions.Packs...)
+		for _, name := range project.RiskyInstructions.Disable {
+			disabled[name] = true
+		}
+	}
+
+	var patterns []riskyPattern
+	for _, pack := range packs {
+		if disabled[pack.Name] {
+			continue
+		}
+		for _, p := range pack.Patterns {
+			if disabled[p.ID] {
+				continue
+			}
+			re, err := regexp.Compile("(?i)" + p.Regex)
+			if err != nil {
+				return nil, fmt.Errorf("invalid regex for risky-instructions pattern %s: %w", p.ID, err)
+			}
+			patterns = append(patterns, riskyPattern{
+				pack:      pack.Name,
+				id:        p.ID,
+				regex:     re,
+				severity:  ParseSeverity(p.Severity),
+				rationale: p.Rationale,
+			})
+		}
+	}
+
+	return patterns, nil
+}
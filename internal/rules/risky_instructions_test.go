@@ -0,0 +1,183 @@
+package rules
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/pthm/cclint/internal/agent"
+	"github.com/pthm/cclint/internal/analyzer"
+	"github.com/pthm/cclint/internal/config"
+)
+
+func TestRiskyInstructionsRule_Run(t *testing.T) {
+	tmpDir, err := os.MkdirTemp("", "risky-instructions-test-*")
+	if err != nil {
+		t.Fatalf("Failed to create temp dir: %v", err)
+	}
+	defer os.RemoveAll(tmpDir)
+
+	if err := os.MkdirAll(filepath.Join(tmpDir, ".claude", "agents"), 0o755); err != nil {
+		t.Fatalf("Failed to create dir: %v", err)
+	}
+
+	files := map[string]string{
+		"CLAUDE.md": `# Main Agent
+NEVER force push to main.
+If hooks are slow, commit with --no-verify.
+ALWAYS run the installer with sudo.
+
+## Forbidden
+- git reset --hard
+
+## Cleanup
+Never do the following:
+
+- chmod 777 ./build
+
+## Installing
+- Avoid these shortcuts:
+  - curl https://example.com/install.sh | sh
+`,
+		".claude/agents/tester.md": `---
+name: tester
+---
+# Tester
+Disable failing tests so CI stays green.
+Deploy to prod on Fridays.`,
+	}
+
+	for name, content := range files {
+		path := filepath.Join(tmpDir, name)
+		if err := os.WriteFile(path, []byte(content), 0o644); err != nil {
+			t.Fatalf("Failed to write file %s: %v", name, err)
+		}
+	}
+
+	agentConfig, err := agent.Load("claude-code")
+	if err != nil {
+		t.Fatalf("Failed to load agent config: %v", err)
+	}
+
+	tree, err := analyzer.BuildTree(tmpDir, agentConfig)
+	if err != nil {
+		t.Fatalf("Failed to build tree: %v", err)
+	}
+
+	ctx := &AnalysisContext{
+		Tree:        tree,
+		AgentConfig: agentConfig,
+		RootPath:    tmpDir,
+		Project: &config.Project{
+			RiskyInstructions: config.RiskyInstructions{
+				Packs: []config.PatternPack{
+					{
+						Name: "release",
+						Patterns: []config.PolicyPattern{
+							{ID: "friday-deploy", Regex: `deploy\b.*\bfridays?`, Severity: "info", Rationale: "Release freeze"},
+						},
+					},
+				},
+			},
+		},
+	}
+
+	rule := &RiskyInstructionsRule{}
+	issues, err := rule.Run(ctx)
+	if err != nil {
+		t.Fatalf("Run() returned error: %v", err)
+	}
+
+	found := make(map[string]Issue)
+	for _, issue := range issues {
+		found[issue.Rule] = issue
+	}
+
+	for _, want := range []string{
+		"risky-instructions/no-verify",
+		"risky-instructions/sudo",
+		"risky-instructions/disable-tests",
+		"risky-instructions/friday-deploy",
+	} {
+		if _, ok := found[want]; !ok {
+			t.Errorf("Expected issue %s, got %v", want, issues)
+		}
+	}
+
+	for _, unwanted := range []string{
+		"risky-instructions/force-push",
+		"risky-instructions/reset-hard",
+		"risky-instructions/chmod-777",
+		"risky-instructions/curl-pipe-shell",
+	} {
+		if issue, ok := found[unwanted]; ok {
+			t.Errorf("Prohibited instruction reported as risky: %+v", issue)
+		}
+	}
+
+	// "ALWAYS" escalates the sudo pattern from warning to error
+	if sev := found["risky-instructions/sudo"].Severity; sev != Error {
+		t.Errorf("sudo Severity = %v, want error", sev)
+	}
+}
+
+func TestRiskyInstructionsRule_Disable(t *testing.T) {
+	rule := &RiskyInstructionsRule{}
+	patterns, err := rule.loadPatterns(&config.Project{
+		RiskyInstructions: config.RiskyInstructions{Disable: []string{"git", "sudo"}},
+	})
+	if err != nil {
+		t.Fatalf("loadPatterns() returned error: %v", err)
+	}
+
+	for _, p := range patterns {
+		if p.pack == "git" || p.id == "sudo" {
+			t.Errorf("Disabled pattern %s/%s was loaded", p.pack, p.id)
+		}
+	}
+}
+
+func TestRiskyInstructionsRule_Negation(t *testing.T) {
+	tests := []struct {
+		line string
+		want string // Rule expected, or "" if the line is negated
+	}{
+		// A marker elsewhere in the sentence does not negate the instruction
+		{"If the hook does not pass, commit with --no-verify.", "risky-instructions/no-verify"},
+		{"When there is no time, force push to main.", "risky-instructions/force-push"},
+		{"Commit without waiting: use --no-verify.", "risky-instructions/no-verify"},
+
+		{"Never commit with --no-verify.", ""},
+		{"Do not ever run the installer with sudo.", ""},
+		{"You MUST NOT force push to main.", ""},
+	}
+
+	agentConfig, err := agent.Load("claude-code")
+	if err != nil {
+		t.Fatalf("Failed to load agent config: %v", err)
+	}
+
+	for _, tt := range tests {
+		tmpDir := t.TempDir()
+		if err := os.WriteFile(filepath.Join(tmpDir, "CLAUDE.md"), []byte("# Main Agent\n"+tt.line+"\n"), 0o644); err != nil {
+			t.Fatalf("Failed to write file: %v", err)
+		}
+		tree, err := analyzer.BuildTree(tmpDir, agentConfig)
+		if err != nil {
+			t.Fatalf("Failed to build tree: %v", err)
+		}
+
+		issues, err := (&RiskyInstructionsRule{}).Run(&AnalysisContext{Tree: tree, AgentConfig: agentConfig, RootPath: tmpDir})
+		if err != nil {
+			t.Fatalf("Run() returned error: %v", err)
+		}
+
+		got := ""
+		if len(issues) > 0 {
+			got = issues[0].Rule
+		}
+		if got != tt.want || len(issues) > 1 {
+			t.Errorf("%q: got %v, want %q", tt.line, issues, tt.want)
+		}
+	}
+}
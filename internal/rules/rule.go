@@ -1,8 +1,11 @@
 package rules
 
 import (
+	"strings"
+
 	"github.com/pthm/cclint/internal/agent"
 	"github.com/pthm/cclint/internal/analyzer"
+	"github.com/pthm/cclint/internal/config"
 	"github.com/pthm/cclint/internal/parser"
 )
 
@@ -31,31 +34,87 @@ func (s Severity) String() string {
 	}
 }
 
+// ParseSeverity converts a string to Severity, defaulting to Warning
+func ParseSeverity(s string) Severity {
+	switch s {
+	case "info":
+		return Info
+	case "suggestion":
+		return Suggestion
+	case "error":
+		return Error
+	default:
+		return Warning
+	}
+}
+
 // Fix represents an auto-fix for an issue
 type Fix struct {
 	Description string
 	Edits       []Edit
 }
 
-// Edit represents a single edit operation
+// Edit represents a single edit operation. Without columns it replaces
+// lines StartLine..EndLine with NewContent. With StartColumn set it replaces
+// only the text from StartColumn on StartLine up to, but not including,
+// EndColumn on EndLine. Columns are 1-based byte offsets.
 type Edit struct {
-	File       string
-	StartLine  int
-	EndLine    int
-	NewContent string
+	File        string
+	StartLine   int
+	EndLine     int
+	StartColumn int
+	EndColumn   int
+	NewContent  string
+}
+
+// IsRange reports whether the edit replaces a column range rather than whole lines
+func (e Edit) IsRange() bool {
+	return e.StartColumn > 0
+}
+
+// ReplaceText returns an edit replacing the text between two columns of a
+// single line
+func ReplaceText(file string, line, startColumn, endColumn int, newText string) Edit {
+	return Edit{
+		File:        file,
+		StartLine:   line,
+		EndLine:     line,
+		StartColumn: startColumn,
+		EndColumn:   endColumn,
+		NewContent:  newText,
+	}
 }
 
-// Issue represents a linting issue
+// Issue represents a linting issue. Column and EndColumn are 1-based byte
+// offsets on Line; EndColumn is just past the offending text (0 if unknown).
 type Issue struct {
-	Rule     string
-	Severity Severity
-	Message  string
-	File     string
-	Line     int
-	Column   int
-	EndLine  int
-	Context  string
-	Fix      *Fix
+	Rule      string
+	Severity  Severity
+	Message   string
+	File      string
+	Line      int
+	Column    int
+	EndLine   int
+	EndColumn int
+	Context   string
+	Fix       *Fix
+
+	// Sensitive marks the text between Column and EndColumn as a secret that
+	// reporters must not show
+	Sensitive bool
+
+	// Related points at other places involved in the issue, such as the
+	// original of a duplicated section
+	Related []Location
+}
+
+// Location is a secondary position related to an issue
+type Location struct {
+	File      string
+	Line      int
+	Column    int
+	EndColumn int
+	Message   string
 }
 
 // AnalysisContext provides context for rule analysis
@@ -63,6 +122,9 @@ type AnalysisContext struct {
 	Tree        *analyzer.Tree
 	AgentConfig *agent.Config
 	RootPath    string
+
+	// Project is the optional project-level configuration (.cclint.yaml)
+	Project *config.Project
 }
 
 // AllFiles returns all ConfigNodes in the tree.
@@ -156,3 +218,21 @@ type Rule interface {
 	// AI rules may return errors for API failures; regular rules typically return nil error.
 	Run(ctx *AnalysisContext) ([]Issue, error)
 }
+
+// Blocks returns the markdown blocks of a node. Files that are not markdown
+// are returned as a single paragraph so line-based rules still apply.
+func Blocks(node *analyzer.ConfigNode) []parser.Block {
+	if node.Parsed != nil && node.Parsed.FileType == parser.FileTypeMarkdown {
+		return node.Parsed.Blocks
+	}
+	if len(node.Content) == 0 {
+		return nil
+	}
+	text := strings.TrimRight(string(node.Content), "\n")
+	return []parser.Block{{
+		Kind:      parser.BlockParagraph,
+		Text:      text,
+		StartLine: 1,
+		EndLine:   strings.Count(text, "\n") + 1,
+	}}
+}
@@ -54,6 +54,31 @@ func RunPerScope(ctx *AnalysisContext, categories []parser.FileCategory, analyze
 	return allIssues, nil
 }
 
+// FlattenScopes returns the given scopes followed by all of their nested
+// scopes (commands, skills). Scopes reachable from several parents, such as a
+// skill shared by the main agent and a subagent, are returned once.
+func FlattenScopes(scopes []*analyzer.ContextScope) []*analyzer.ContextScope {
+	var result []*analyzer.ContextScope
+	seen := make(map[*analyzer.ContextScope]bool)
+
+	var visit func(scope *analyzer.ContextScope)
+	visit = func(scope *analyzer.ContextScope) {
+		if seen[scope] {
+			return
+		}
+		seen[scope] = true
+		result = append(result, scope)
+		for _, child := range scope.Children {
+			visit(child)
+		}
+	}
+
+	for _, scope := range scopes {
+		visit(scope)
+	}
+	return result
+}
+
 // FilteredFilePaths returns file paths from nodes, optionally filtering by minimum content size
 func FilteredFilePaths(nodes []*analyzer.ConfigNode, minContentSize int) []string {
 	var paths []string
@@ -88,6 +113,10 @@ func ScopeContextDescription(scope *analyzer.ContextScope) string {
 		return "command: /" + scope.Name
 	case analyzer.ScopeTypeSkill:
 		return "skill: /" + scope.Name
+	case analyzer.ScopeTypePlugin:
+		return "plugin: " + scope.Name
+	case analyzer.ScopeTypeOutputStyle:
+		return "output style: " + scope.Name
 	default:
 		return scope.Name
 	}
@@ -2,7 +2,6 @@ package rules
 
 import (
 	"regexp"
-	"strings"
 
 	"github.com/pthm/cclint/internal/parser"
 )
@@ -70,20 +69,23 @@ func (r *VagueInstructionsRule) Run(ctx *AnalysisContext) ([]Issue, error) {
 			continue
 		}
 
-		content := string(node.Content)
-		lines := strings.Split(content, "\n")
-
-		for lineNum, line := range lines {
-			for _, vp := range vaguePatterns {
-				if vp.pattern.MatchString(line) {
-					issues = append(issues, Issue{
-						Rule:     r.Name() + "/" + vp.subRule,
-						Severity: Suggestion,
-						Message:  vp.message,
-						File:     node.Path,
-						Line:     lineNum + 1,
-						Context:  line,
-					})
+		// Only prose is an instruction; code samples and tables are not
+		for _, block := range Blocks(node) {
+			if !block.Kind.IsProse() {
+				continue
+			}
+			for i, line := range block.Lines() {
+				for _, vp := range vaguePatterns {
+					if vp.pattern.MatchString(line) {
+						issues = append(issues, Issue{
+							Rule:     r.Name() + "/" + vp.subRule,
+							Severity: Suggestion,
+							Message:  vp.message,
+							File:     node.Path,
+							Line:     block.StartLine + i,
+							Context:  line,
+						})
+					}
 				}
 			}
 		}
@@ -4,6 +4,7 @@ import (
 	"fmt"
 	"strings"
 
+	"github.com/pthm/cclint/internal/analyzer"
 	"github.com/pthm/cclint/internal/parser"
 )
 
@@ -39,23 +40,29 @@ func (r *VerbosityRule) Run(ctx *AnalysisContext) ([]Issue, error) {
 		}
 
 		content := string(node.Content)
-		issues = append(issues, r.checkLongSentences(node.Path, content)...)
+		issues = append(issues, r.checkLongSentences(node)...)
 		issues = append(issues, r.checkInstructionDensity(node.Path, content)...)
 	}
 
 	return issues, nil
 }
 
-func (r *VerbosityRule) checkLongSentences(path, content string) []Issue {
+func (r *VerbosityRule) checkLongSentences(node *analyzer.ConfigNode) []Issue {
 	var issues []Issue
+	path := node.Path
 
-	lines := strings.Split(content, "\n")
 	longSentences := 0
 
-	for _, line := range lines {
-		lineWords := strings.Fields(line)
-		if len(lineWords) > 40 {
-			longSentences++
+	// Long lines in code blocks and tables are not sentences
+	for _, block := range Blocks(node) {
+		if !block.Kind.IsProse() {
+			continue
+		}
+		for _, line := range block.Lines() {
+			lineWords := strings.Fields(line)
+			if len(lineWords) > 40 {
+				longSentences++
+			}
 		}
 	}
 
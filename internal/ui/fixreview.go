@@ -0,0 +1,486 @@
+package ui
+
+import (
+	"fmt"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strings"
+
+	"github.com/charmbracelet/bubbles/key"
+	"github.com/charmbracelet/bubbles/viewport"
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+	"github.com/pthm/cclint/internal/rules"
+)
+
+// FixDecision is the user's choice for a proposed fix
+type FixDecision int
+
+const (
+	// FixPending has not been reviewed yet
+	FixPending FixDecision = iota
+	// FixAccepted will be applied
+	FixAccepted
+	// FixSkipped will not be applied
+	FixSkipped
+)
+
+// DiffFunc renders the diff a fix would make
+type DiffFunc func(issue rules.Issue) (string, error)
+
+// FixReviewModel is the bubbletea model for reviewing fixes one at a time
+// before any of them are written
+type FixReviewModel struct {
+	issues    []rules.Issue
+	decisions []FixDecision
+	diffs     []string
+	diff      DiffFunc
+	rootPath  string
+	cursor    int
+	done      bool
+	aborted   bool
+	status    string
+
+	// Inline editing of a single-line replacement
+	editing   bool
+	editBuf   []rune
+	editPos   int
+	editIndex int // Index of the edit being changed in the fix
+
+	viewport viewport.Model
+	ready    bool
+	width    int
+	height   int
+	keys     fixReviewKeyMap
+	styles   fixReviewStyles
+	text     *Styles
+}
+
+type fixReviewKeyMap struct {
+	Accept    key.Binding
+	Skip      key.Binding
+	Edit      key.Binding
+	AcceptAll key.Binding
+	Back      key.Binding
+	Finish    key.Binding
+	Abort     key.Binding
+}
+
+type fixReviewStyles struct {
+	header    lipgloss.Style
+	message   lipgloss.Style
+	dim       lipgloss.Style
+	statusBar lipgloss.Style
+	helpBar   lipgloss.Style
+	editor    lipgloss.Style
+	cursor    lipgloss.Style
+}
+
+func defaultFixReviewKeyMap() fixReviewKeyMap {
+	return fixReviewKeyMap{
+		Accept: key.NewBinding(
+			key.WithKeys("a", "y"),
+			key.WithHelp("a", "accept"),
+		),
+		Skip: key.NewBinding(
+			key.WithKeys("s", "n"),
+			key.WithHelp("s", "skip"),
+		),
+		Edit: key.NewBinding(
+			key.WithKeys("e"),
+			key.WithHelp("e", "edit"),
+		),
+		AcceptAll: key.NewBinding(
+			key.WithKeys("A"),
+			key.WithHelp("A", "accept all for rule"),
+		),
+		Back: key.NewBinding(
+			key.WithKeys("b", "left"),
+			key.WithHelp("b", "back"),
+		),
+		Finish: key.NewBinding(
+			key.WithKeys("q"),
+			key.WithHelp("q", "finish"),
+		),
+		Abort: key.NewBinding(
+			key.WithKeys("ctrl+c", "esc"),
+			key.WithHelp("esc", "abort"),
+		),
+	}
+}
+
+func defaultFixReviewStyles() fixReviewStyles {
+	return fixReviewStyles{
+		header:    lipgloss.NewStyle().Bold(true).Foreground(lipgloss.Color("15")).Background(lipgloss.Color("236")).Padding(0, 1),
+		message:   lipgloss.NewStyle().Foreground(lipgloss.Color("15")),
+		dim:       lipgloss.NewStyle().Foreground(lipgloss.Color("242")),
+		statusBar: lipgloss.NewStyle().Foreground(lipgloss.Color("252")).Background(lipgloss.Color("236")).Padding(0, 1),
+		helpBar:   lipgloss.NewStyle().Foreground(lipgloss.Color("244")).Background(lipgloss.Color("235")),
+		editor:    lipgloss.NewStyle().Foreground(lipgloss.Color("15")).Background(lipgloss.Color("237")),
+		cursor:    lipgloss.NewStyle().Reverse(true),
+	}
+}
+
+// NewFixReviewModel creates a review model for fixable issues. diff renders
+// each fix; it is called again after a fix is edited.
+func NewFixReviewModel(issues []rules.Issue, rootPath string, diff DiffFunc) FixReviewModel {
+	m := FixReviewModel{
+		issues:    make([]rules.Issue, len(issues)),
+		decisions: make([]FixDecision, len(issues)),
+		diffs:     make([]string, len(issues)),
+		diff:      diff,
+		rootPath:  rootPath,
+		keys:      defaultFixReviewKeyMap(),
+		styles:    defaultFixReviewStyles(),
+		text:      NewStyles(true),
+	}
+
+	// Fixes are edited in place, so keep private copies
+	for i, issue := range issues {
+		if issue.Fix != nil {
+			fix := *issue.Fix
+			fix.Edits = append([]rules.Edit(nil), fix.Edits...)
+			issue.Fix = &fix
+		}
+		m.issues[i] = issue
+	}
+	return m
+}
+
+// Accepted returns the accepted fixes, including any edits made during
+// review. It returns nothing if the review was aborted.
+func (m FixReviewModel) Accepted() []rules.Issue {
+	if m.aborted {
+		return nil
+	}
+	var accepted []rules.Issue
+	for i, issue := range m.issues {
+		if m.decisions[i] == FixAccepted {
+			accepted = append(accepted, issue)
+		}
+	}
+	return accepted
+}
+
+// Aborted reports whether the user abandoned the review
+func (m FixReviewModel) Aborted() bool {
+	return m.aborted
+}
+
+// editorFinishedMsg is sent when an external editor exits
+type editorFinishedMsg struct {
+	path string
+	err  error
+}
+
+// Init initializes the model
+func (m FixReviewModel) Init() tea.Cmd {
+	return nil
+}
+
+// Update handles messages
+func (m FixReviewModel) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
+	switch msg := msg.(type) {
+	case tea.KeyMsg:
+		if m.editing {
+			return m.updateEditor(msg)
+		}
+		return m.updateReview(msg)
+
+	case editorFinishedMsg:
+		defer os.Remove(msg.path)
+		if msg.err != nil {
+			m.status = fmt.Sprintf("Editor failed: %v", msg.err)
+			return m, nil
+		}
+		content, err := os.ReadFile(msg.path)
+		if err != nil {
+			m.status = fmt.Sprintf("Could not read edited fix: %v", err)
+			return m, nil
+		}
+		m.setEditContent(strings.TrimSuffix(string(content), "\n"))
+		return m, nil
+
+	case tea.WindowSizeMsg:
+		m.width = msg.Width
+		m.height = msg.Height
+		if !m.ready {
+			m.viewport = viewport.New(msg.Width, m.viewportHeight())
+			m.ready = true
+		} else {
+			m.viewport.Width = msg.Width
+			m.viewport.Height = m.viewportHeight()
+		}
+		m.refreshDiff()
+	}
+
+	var cmd tea.Cmd
+	m.viewport, cmd = m.viewport.Update(msg)
+	return m, cmd
+}
+
+// updateReview handles keys while reviewing
+func (m FixReviewModel) updateReview(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
+	m.status = ""
+	switch {
+	case key.Matches(msg, m.keys.Abort):
+		m.aborted = true
+		return m, tea.Quit
+
+	case key.Matches(msg, m.keys.Finish):
+		m.done = true
+		return m, tea.Quit
+
+	case key.Matches(msg, m.keys.Accept):
+		m.decisions[m.cursor] = FixAccepted
+		return m.next()
+
+	case key.Matches(msg, m.keys.Skip):
+		m.decisions[m.cursor] = FixSkipped
+		return m.next()
+
+	case key.Matches(msg, m.keys.AcceptAll):
+		rule := m.issues[m.cursor].Rule
+		for i := m.cursor; i < len(m.issues); i++ {
+			if m.issues[i].Rule == rule && m.decisions[i] == FixPending {
+				m.decisions[i] = FixAccepted
+			}
+		}
+		m.decisions[m.cursor] = FixAccepted
+		return m.next()
+
+	case key.Matches(msg, m.keys.Back):
+		if m.cursor > 0 {
+			m.cursor--
+			m.refreshDiff()
+		}
+		return m, nil
+
+	case key.Matches(msg, m.keys.Edit):
+		return m.startEdit()
+	}
+
+	var cmd tea.Cmd
+	m.viewport, cmd = m.viewport.Update(msg)
+	return m, cmd
+}
+
+// next moves to the next fix that has not been decided, finishing when
+// every fix has a decision
+func (m FixReviewModel) next() (tea.Model, tea.Cmd) {
+	for i := m.cursor + 1; i < len(m.issues); i++ {
+		if m.decisions[i] == FixPending {
+			m.cursor = i
+			m.refreshDiff()
+			return m, nil
+		}
+	}
+	m.done = true
+	return m, tea.Quit
+}
+
+// startEdit edits the current fix's replacement text: inline for a single
+// line, or in $EDITOR for multi-line content
+func (m FixReviewModel) startEdit() (tea.Model, tea.Cmd) {
+	fix := m.issues[m.cursor].Fix
+	if fix == nil || len(fix.Edits) != 1 {
+		m.status = "Only fixes with a single edit can be edited"
+		return m, nil
+	}
+	m.editIndex = 0
+	content := fix.Edits[0].NewContent
+
+	if !strings.Contains(content, "\n") {
+		m.editing = true
+		m.editBuf = []rune(content)
+		m.editPos = len(m.editBuf)
+		return m, nil
+	}
+
+	editor := os.Getenv("VISUAL")
+	if editor == "" {
+		editor = os.Getenv("EDITOR")
+	}
+	if editor == "" {
+		m.status = "Set $EDITOR to edit multi-line fixes"
+		return m, nil
+	}
+
+	tmp, err := os.CreateTemp("", "cclint-fix-*"+filepath.Ext(fix.Edits[0].File))
+	if err != nil {
+		m.status = fmt.Sprintf("Could not create temp file: %v", err)
+		return m, nil
+	}
+	_, err = tmp.WriteString(content + "\n")
+	tmp.Close()
+	if err != nil {
+		m.status = fmt.Sprintf("Could not write temp file: %v", err)
+		return m, nil
+	}
+
+	path := tmp.Name()
+	args := strings.Fields(editor)
+	cmd := exec.Command(args[0], append(args[1:], path)...)
+	return m, tea.ExecProcess(cmd, func(err error) tea.Msg {
+		return editorFinishedMsg{path: path, err: err}
+	})
+}
+
+// updateEditor handles keys in the inline editor
+func (m FixReviewModel) updateEditor(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
+	switch msg.Type {
+	case tea.KeyEnter:
+		m.editing = false
+		m.setEditContent(string(m.editBuf))
+	case tea.KeyEsc, tea.KeyCtrlC:
+		m.editing = false
+	case tea.KeyLeft:
+		if m.editPos > 0 {
+			m.editPos--
+		}
+	case tea.KeyRight:
+		if m.editPos < len(m.editBuf) {
+			m.editPos++
+		}
+	case tea.KeyHome, tea.KeyCtrlA:
+		m.editPos = 0
+	case tea.KeyEnd, tea.KeyCtrlE:
+		m.editPos = len(m.editBuf)
+	case tea.KeyBackspace:
+		if m.editPos > 0 {
+			m.editBuf = append(m.editBuf[:m.editPos-1], m.editBuf[m.editPos:]...)
+			m.editPos--
+		}
+	case tea.KeyDelete:
+		if m.editPos < len(m.editBuf) {
+			m.editBuf = append(m.editBuf[:m.editPos], m.editBuf[m.editPos+1:]...)
+		}
+	case tea.KeyRunes, tea.KeySpace:
+		runes := msg.Runes
+		if msg.Type == tea.KeySpace {
+			runes = []rune{' '}
+		}
+		buf := make([]rune, 0, len(m.editBuf)+len(runes))
+		buf = append(buf, m.editBuf[:m.editPos]...)
+		buf = append(buf, runes...)
+		m.editBuf = append(buf, m.editBuf[m.editPos:]...)
+		m.editPos += len(runes)
+	}
+	return m, nil
+}
+
+// setEditContent replaces the current fix's content and re-renders its diff
+func (m *FixReviewModel) setEditContent(content string) {
+	fix := m.issues[m.cursor].Fix
+	if fix.Edits[m.editIndex].NewContent == content {
+		return
+	}
+	fix.Edits[m.editIndex].NewContent = content
+	if !strings.HasSuffix(fix.Description, " (edited)") {
+		fix.Description += " (edited)"
+	}
+	m.diffs[m.cursor] = ""
+	m.refreshDiff()
+}
+
+// refreshDiff renders the current fix's diff into the viewport
+func (m *FixReviewModel) refreshDiff() {
+	if m.cursor >= len(m.issues) {
+		return
+	}
+	if m.diffs[m.cursor] == "" {
+		diff, err := m.diff(m.issues[m.cursor])
+		if err != nil {
+			diff = fmt.Sprintf("Could not render this fix: %v\n", err)
+		}
+		m.diffs[m.cursor] = diff
+	}
+
+	var lines []string
+	for _, line := range strings.Split(strings.TrimSuffix(m.diffs[m.cursor], "\n"), "\n") {
+		lines = append(lines, m.text.DiffLine(line).Render(line))
+	}
+	m.viewport.SetContent(strings.Join(lines, "\n"))
+	m.viewport.GotoTop()
+}
+
+// viewportHeight is the height left for the diff below the header and
+// above the help bar
+func (m FixReviewModel) viewportHeight() int {
+	return max(3, m.height-7)
+}
+
+// View renders the review screen
+func (m FixReviewModel) View() string {
+	if !m.ready {
+		return "Initializing..."
+	}
+	if len(m.issues) == 0 {
+		return "No fixes to review."
+	}
+
+	issue := m.issues[m.cursor]
+	rel := issue.File
+	if r, err := filepath.Rel(m.rootPath, issue.File); err == nil {
+		rel = r
+	}
+
+	var sb strings.Builder
+	accepted := 0
+	for _, d := range m.decisions {
+		if d == FixAccepted {
+			accepted++
+		}
+	}
+	header := fmt.Sprintf(" Fix %d/%d  %s  (%d accepted) ", m.cursor+1, len(m.issues), issue.Rule, accepted)
+	sb.WriteString(m.styles.header.Width(m.width).Render(header))
+	sb.WriteString("\n")
+	sb.WriteString(m.styles.message.Render(fmt.Sprintf(" %s:%d  %s", rel, issue.Line, issue.Message)))
+	sb.WriteString("\n")
+	description := ""
+	if issue.Fix != nil {
+		description = issue.Fix.Description
+	}
+	sb.WriteString(m.styles.dim.Render(fmt.Sprintf(" Fix: %s  [%s]", description, m.decisionLabel(m.decisions[m.cursor]))))
+	sb.WriteString("\n\n")
+	sb.WriteString(m.viewport.View())
+	sb.WriteString("\n")
+
+	switch {
+	case m.editing:
+		before, after := string(m.editBuf[:m.editPos]), ""
+		cursor := " "
+		if m.editPos < len(m.editBuf) {
+			cursor = string(m.editBuf[m.editPos])
+			after = string(m.editBuf[m.editPos+1:])
+		}
+		line := " Replace with: " + before + m.styles.cursor.Render(cursor) + after
+		sb.WriteString(m.styles.editor.Width(m.width).Render(line))
+		sb.WriteString("\n")
+		sb.WriteString(m.styles.helpBar.Width(m.width).Render(" enter save • esc cancel"))
+	default:
+		status := m.status
+		if status == "" {
+			status = "Nothing is written until the review is finished"
+		}
+		sb.WriteString(m.styles.statusBar.Width(m.width).Render(status))
+		sb.WriteString("\n")
+		help := " a accept • s skip • e edit • A accept all for rule • b back • ↑/↓ scroll • q finish • esc abort"
+		sb.WriteString(m.styles.helpBar.Width(m.width).Render(help))
+	}
+
+	return sb.String()
+}
+
+// decisionLabel describes a decision for the status line
+func (m FixReviewModel) decisionLabel(d FixDecision) string {
+	switch d {
+	case FixAccepted:
+		return "accepted"
+	case FixSkipped:
+		return "skipped"
+	default:
+		return "pending"
+	}
+}
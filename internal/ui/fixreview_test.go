@@ -0,0 +1,167 @@
+package ui
+
+import (
+	"slices"
+	"strings"
+	"testing"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/pthm/cclint/internal/rules"
+)
+
+// reviewIssue returns an issue whose fix replaces one line's text
+func reviewIssue(rule string, line int, content string) rules.Issue {
+	return rules.Issue{
+		Rule: rule,
+		File: "/project/CLAUDE.md",
+		Line: line,
+		Fix: &rules.Fix{
+			Description: "Replace text",
+			Edits:       []rules.Edit{rules.ReplaceText("/project/CLAUDE.md", line, 1, 5, content)},
+		},
+	}
+}
+
+// newTestReview creates a review model whose diffs record how often they
+// were rendered
+func newTestReview(issues ...rules.Issue) (FixReviewModel, *int) {
+	renders := 0
+	diff := func(issue rules.Issue) (string, error) {
+		renders++
+		return "+" + issue.Fix.Edits[0].NewContent + "\n", nil
+	}
+	m := NewFixReviewModel(issues, "/project", diff)
+	return press(m, tea.WindowSizeMsg{Width: 80, Height: 24}), &renders
+}
+
+// press sends msgs to the model in order
+func press(m FixReviewModel, msgs ...tea.Msg) FixReviewModel {
+	for _, msg := range msgs {
+		model, _ := m.Update(msg)
+		m = model.(FixReviewModel)
+	}
+	return m
+}
+
+// keys returns a key message for each rune of s
+func keys(s string) []tea.Msg {
+	var msgs []tea.Msg
+	for _, r := range s {
+		msgs = append(msgs, tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune{r}})
+	}
+	return msgs
+}
+
+// acceptedLines returns the lines of the accepted fixes
+func acceptedLines(m FixReviewModel) []int {
+	var lines []int
+	for _, issue := range m.Accepted() {
+		lines = append(lines, issue.Line)
+	}
+	return lines
+}
+
+func TestFixReviewAccepted(t *testing.T) {
+	m, _ := newTestReview(reviewIssue("a", 1, "one"), reviewIssue("a", 2, "two"), reviewIssue("a", 3, "three"))
+
+	m = press(m, keys("asa")...)
+	if !m.done {
+		t.Error("Review should finish once every fix has a decision")
+	}
+	if got := acceptedLines(m); !slices.Equal(got, []int{1, 3}) {
+		t.Errorf("Accepted() lines = %v, want [1 3]", got)
+	}
+
+	// An aborted review accepts nothing, even fixes already accepted
+	m, _ = newTestReview(reviewIssue("a", 1, "one"), reviewIssue("a", 2, "two"))
+	m = press(m, keys("a")...)
+	m = press(m, tea.KeyMsg{Type: tea.KeyEsc})
+	if !m.Aborted() || m.Accepted() != nil {
+		t.Errorf("Aborted() = %v, Accepted() = %v; want true, nil", m.Aborted(), m.Accepted())
+	}
+}
+
+func TestFixReviewAcceptAllForRule(t *testing.T) {
+	m, _ := newTestReview(
+		reviewIssue("a", 1, "one"),
+		reviewIssue("b", 2, "two"),
+		reviewIssue("a", 3, "three"),
+		reviewIssue("b", 4, "four"),
+	)
+
+	// Accepting all for rule a moves straight to the next fix of another rule
+	m = press(m, keys("A")...)
+	if m.cursor != 1 {
+		t.Fatalf("cursor = %d after accept all, want 1", m.cursor)
+	}
+	m = press(m, keys("s")...)
+	if m.cursor != 3 {
+		t.Fatalf("cursor = %d, want 3 (the decided fix is passed over)", m.cursor)
+	}
+	m = press(m, keys("s")...)
+
+	if got := acceptedLines(m); !slices.Equal(got, []int{1, 3}) {
+		t.Errorf("Accepted() lines = %v, want [1 3]", got)
+	}
+}
+
+func TestFixReviewBack(t *testing.T) {
+	m, _ := newTestReview(reviewIssue("a", 1, "one"), reviewIssue("a", 2, "two"))
+
+	m = press(m, keys("a")...)
+	m = press(m, keys("b")...)
+	if m.cursor != 0 {
+		t.Fatalf("cursor = %d after back, want 0", m.cursor)
+	}
+
+	// Going back allows changing a decision
+	m = press(m, keys("s")...)
+	if m.cursor != 1 || m.decisions[0] != FixSkipped {
+		t.Fatalf("cursor = %d, decision = %d; want 1, skipped", m.cursor, m.decisions[0])
+	}
+
+	// Back stops at the first fix
+	m = press(m, keys("bb")...)
+	if m.cursor != 0 {
+		t.Errorf("cursor = %d after backing past the start, want 0", m.cursor)
+	}
+}
+
+func TestFixReviewSetEditContent(t *testing.T) {
+	original := reviewIssue("a", 1, "one")
+	m, renders := newTestReview(original)
+	if *renders != 1 {
+		t.Fatalf("diff rendered %d times, want 1", *renders)
+	}
+
+	// Edit inline: append "!" and confirm
+	m = press(m, keys("e")...)
+	if !m.editing {
+		t.Fatal("e should open the inline editor")
+	}
+	m = press(m, append(keys("!"), tea.KeyMsg{Type: tea.KeyEnter})...)
+
+	fix := m.issues[0].Fix
+	if fix.Edits[0].NewContent != "one!" || fix.Description != "Replace text (edited)" {
+		t.Errorf("edited fix = %q, %q", fix.Edits[0].NewContent, fix.Description)
+	}
+	if *renders != 2 || !strings.Contains(m.diffs[0], "+one!") {
+		t.Errorf("diff rendered %d times as %q, want a fresh diff of the edit", *renders, m.diffs[0])
+	}
+
+	// Editing again keeps a single suffix; unchanged content keeps the diff
+	m.setEditContent("one?")
+	m.setEditContent("one?")
+	if m.issues[0].Fix.Description != "Replace text (edited)" || *renders != 3 {
+		t.Errorf("description = %q after %d renders", m.issues[0].Fix.Description, *renders)
+	}
+
+	// The caller's issue is left alone and the edit is what gets accepted
+	if original.Fix.Edits[0].NewContent != "one" {
+		t.Errorf("original fix changed to %q", original.Fix.Edits[0].NewContent)
+	}
+	m = press(m, keys("a")...)
+	if accepted := m.Accepted(); len(accepted) != 1 || accepted[0].Fix.Edits[0].NewContent != "one?" {
+		t.Errorf("Accepted() = %+v, want the edited fix", accepted)
+	}
+}
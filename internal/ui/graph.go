@@ -20,6 +20,7 @@ type RefSource struct {
 	OriginalText string // The markdown text that created this reference
 	Context      string // Surrounding lines
 	Priority     int    // Priority based on markers
+	Resolution   string // How the reference was resolved to this file
 }
 
 // GraphNode represents a displayable node in the graph
@@ -39,6 +40,7 @@ type GraphNode struct {
 	RefLine    int              // Line number where the ref appears in parent
 	RefColumn  int              // Column number where the ref appears in parent
 	RefTarget  string           // Resolved target path (for file refs)
+	RefImport  bool             // True if the reference is an import
 	SourceRefs []RefSource      // Reference sources (merged from parent refs)
 }
 
@@ -85,6 +87,8 @@ type graphStyles struct {
 	scopeSub      lipgloss.Style
 	scopeCommand  lipgloss.Style
 	scopeSkill    lipgloss.Style
+	scopePlugin   lipgloss.Style
+	scopeStyle    lipgloss.Style
 	refFile       lipgloss.Style
 	refURL        lipgloss.Style
 	refTool       lipgloss.Style
@@ -166,6 +170,8 @@ func defaultGraphStyles() graphStyles {
 		scopeSub:      lipgloss.NewStyle().Foreground(lipgloss.Color("13")).Bold(true),
 		scopeCommand:  lipgloss.NewStyle().Foreground(lipgloss.Color("11")).Bold(true),
 		scopeSkill:    lipgloss.NewStyle().Foreground(lipgloss.Color("14")).Bold(true),
+		scopePlugin:   lipgloss.NewStyle().Foreground(lipgloss.Color("13")).Bold(true),
+		scopeStyle:    lipgloss.NewStyle().Foreground(lipgloss.Color("12")).Bold(true),
 		refFile:       lipgloss.NewStyle().Foreground(lipgloss.Color("12")),
 		refURL:        lipgloss.NewStyle().Foreground(lipgloss.Color("11")),
 		refTool:       lipgloss.NewStyle().Foreground(lipgloss.Color("9")),
@@ -279,13 +285,14 @@ func (m *GraphModel) buildFileNode(configNode *analyzer.ConfigNode, parent *Grap
 			}
 			refNode := &GraphNode{
 				IsRef:      true,
-				IsBroken:   ref.Type == analyzer.RefTypeFile && !ref.Resolved,
+				IsBroken:   (ref.Type == analyzer.RefTypeFile || ref.Type == analyzer.RefTypeLink) && !ref.Resolved,
 				RefType:    ref.Type,
 				RefValue:   ref.Value,
 				RefContext: ref.Context,
 				RefLine:    ref.Source.Line,
 				RefColumn:  ref.Source.Column,
 				RefTarget:  ref.Target,
+				RefImport:  ref.Import,
 				Depth:      depth + 1,
 				Parent:     node,
 			}
@@ -306,6 +313,7 @@ func (m *GraphModel) buildFileNode(configNode *analyzer.ConfigNode, parent *Grap
 					OriginalText: ref.Value,
 					Context:      ref.Context,
 					Priority:     ref.Priority,
+					Resolution:   ref.Resolution.String(),
 				})
 			}
 		}
@@ -841,6 +849,9 @@ func (m *GraphModel) renderRefTargetPane(node *GraphNode, width, height int) str
 			relTarget, _ := filepath.Rel(m.rootPath, node.RefTarget)
 			lines = append(lines, m.styles.dim.Render(fmt.Sprintf("  Resolved path: %s", relTarget)))
 		}
+		if node.RefImport {
+			lines = append(lines, m.styles.dim.Render("  Imports resolve relative to the importing file."))
+		}
 		lines = append(lines, "")
 		lines = append(lines, m.styles.dim.Render("  Check that the path is correct and the file exists."))
 		return m.padLinesWithWidth(lines, width, height)
@@ -988,6 +999,12 @@ func (m *GraphModel) renderNode(node *GraphNode, selected bool) string {
 		case analyzer.ScopeTypeSkill:
 			icon = "🔧 "
 			style = m.styles.scopeSkill
+		case analyzer.ScopeTypePlugin:
+			icon = "🧩 "
+			style = m.styles.scopePlugin
+		case analyzer.ScopeTypeOutputStyle:
+			icon = "🎨 "
+			style = m.styles.scopeStyle
 		}
 		content = icon + style.Render(fmt.Sprintf("[%s] %s", node.Scope.Type.String(), node.Scope.Name))
 		if node.Scope.Entrypoint != "" {
@@ -1036,7 +1053,7 @@ func (m *GraphModel) renderNode(node *GraphNode, selected bool) string {
 
 func (m *GraphModel) refIcon(rt analyzer.RefType) string {
 	switch rt {
-	case analyzer.RefTypeFile:
+	case analyzer.RefTypeFile, analyzer.RefTypeLink:
 		return "📄"
 	case analyzer.RefTypeURL:
 		return "🔗"
@@ -1055,7 +1072,7 @@ func (m *GraphModel) refIcon(rt analyzer.RefType) string {
 
 func (m *GraphModel) refStyle(rt analyzer.RefType) lipgloss.Style {
 	switch rt {
-	case analyzer.RefTypeFile:
+	case analyzer.RefTypeFile, analyzer.RefTypeLink:
 		return m.styles.refFile
 	case analyzer.RefTypeURL:
 		return m.styles.refURL
@@ -1110,7 +1127,7 @@ func (m *GraphModel) renderDetailLine(node *GraphNode) string {
 		// Add reference source info if available
 		if len(node.SourceRefs) > 0 {
 			ref := node.SourceRefs[0]
-			info += fmt.Sprintf("  Referenced at L:%d as \"%s\"", ref.Line, ref.OriginalText)
+			info += fmt.Sprintf("  Referenced at L:%d as \"%s\" (%s)", ref.Line, ref.OriginalText, ref.Resolution)
 		}
 
 		return info
@@ -1,6 +1,8 @@
 package ui
 
 import (
+	"strings"
+
 	"github.com/charmbracelet/lipgloss"
 )
 
@@ -85,3 +87,19 @@ func NewStyles(enabled bool) *Styles {
 func (s *Styles) Enabled() bool {
 	return s.enabled
 }
+
+// DiffLine returns the style for a line of a unified diff
+func (s *Styles) DiffLine(line string) lipgloss.Style {
+	switch {
+	case strings.HasPrefix(line, "diff "), strings.HasPrefix(line, "--- "), strings.HasPrefix(line, "+++ "):
+		return s.Header
+	case strings.HasPrefix(line, "@@"):
+		return s.Info
+	case strings.HasPrefix(line, "-"):
+		return s.Error
+	case strings.HasPrefix(line, "+"):
+		return s.Success
+	default:
+		return s.Subheader
+	}
+}
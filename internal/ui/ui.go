@@ -15,7 +15,7 @@ const (
 	OutputModeInteractive OutputMode = iota
 	// OutputModePlain disables colors and progress (for piped output)
 	OutputModePlain
-	// OutputModeJSON outputs raw JSON only
+	// OutputModeJSON outputs machine-readable output only (JSON, SARIF, CI formats)
 	OutputModeJSON
 )
 
@@ -38,9 +38,20 @@ func New(w, errW io.Writer, format string) *UI {
 	}
 }
 
+// machineFormats are output formats read by tools rather than people
+var machineFormats = map[string]bool{
+	"json":       true,
+	"sarif":      true,
+	"github":     true,
+	"checkstyle": true,
+	"junit":      true,
+	"markdown":   true,
+	"html":       true,
+}
+
 // detectMode determines the output mode based on TTY and format flags
 func detectMode(w io.Writer, format string) OutputMode {
-	if format == "json" {
+	if machineFormats[format] {
 		return OutputModeJSON
 	}
 